@@ -25,3 +25,57 @@ app.kubernetes.io/instance: {{ .Release.Name }}
 {{- define "leaderelectionid" -}}
 gardener-extension-admission-metal
 {{- end -}}
+
+{{- /*
+  legacyShootDefaults translates the deprecated defaulter values, which were passed as environment variables to the
+  admission component, into the shoot defaults of the admission configuration.
+*/ -}}
+{{- define "legacyShootDefaults" -}}
+{{- $defaulter := .Values.defaulter | default dict -}}
+{{- $defaults := dict "calico" (dict) "cilium" (dict) -}}
+{{- range $m := list
+  (list "maxPods" "" "maxPods" "int")
+  (list "nodeCIDRMaskSize" "" "nodeCIDRMaskSize" "int")
+  (list "podsCIDR" "" "podsCIDR" "string")
+  (list "servicesCIDR" "" "servicesCIDR" "string")
+  (list "networkType" "" "networkType" "string")
+  (list "calicoBackend" "calico" "backend" "string")
+  (list "calicoKubeProxyEnabled" "calico" "kubeProxyEnabled" "bool")
+  (list "calicoPoolMode" "calico" "poolMode" "string")
+  (list "calicoTyphaEnabled" "calico" "typhaEnabled" "bool")
+  (list "ciliumHubbleEnabled" "cilium" "hubbleEnabled" "bool")
+  (list "ciliumKubeProxyEnabled" "cilium" "kubeProxyEnabled" "bool")
+  (list "ciliumTunnel" "cilium" "tunnelMode" "string")
+  (list "ciliumDevices" "cilium" "devices" "list")
+  (list "ciliumDirectRoutingDevice" "cilium" "directRoutingDevice" "string")
+  (list "ciliumBGPControlPlane" "cilium" "bgpControlPlaneEnabled" "bool")
+  (list "ciliumIPv4NativeRoutingCIDREnabled" "cilium" "ipv4NativeRoutingCIDREnabled" "bool")
+  (list "ciliumLoadBalancingMode" "cilium" "loadBalancingMode" "string")
+  (list "ciliumMTU" "cilium" "mtu" "int")
+-}}
+{{- $value := index $defaulter (index $m 0) -}}
+{{- if ne nil $value -}}
+{{- $target := $defaults -}}
+{{- if index $m 1 -}}
+{{- $target = index $defaults (index $m 1) -}}
+{{- end -}}
+{{- $type := index $m 3 -}}
+{{- if eq $type "int" -}}
+{{- $value = int $value -}}
+{{- else if eq $type "bool" -}}
+{{- $value = has (lower (toString $value)) (list "1" "t" "true") -}}
+{{- else if and (eq $type "list") (not (kindIs "slice" $value)) -}}
+{{- $value = splitList "," (toString $value) -}}
+{{- else if eq $type "string" -}}
+{{- $value = toString $value -}}
+{{- end -}}
+{{- $_ := set $target (index $m 2) $value -}}
+{{- end -}}
+{{- end -}}
+{{- range $key := list "calico" "cilium" -}}
+{{- if not (index $defaults $key) -}}
+{{- $_ := unset $defaults $key -}}
+{{- end -}}
+{{- end -}}
+{{- toYaml $defaults -}}
+{{- end -}}
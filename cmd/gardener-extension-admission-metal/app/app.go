@@ -6,6 +6,7 @@ import (
 	"os"
 
 	admissioncmd "github.com/metal-stack/gardener-extension-provider-metal/pkg/admission/cmd"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/admission/mutator"
 	metalinstall "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/install"
 	providermetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
 
@@ -52,6 +53,7 @@ func NewAdmissionCommand(ctx context.Context) *cobra.Command {
 		webhookServerOptions = &webhookcmd.ServerOptions{
 			Namespace: os.Getenv("WEBHOOK_CONFIG_NAMESPACE"),
 		}
+		configFileOpts  = &admissioncmd.AdmissionConfigOptions{}
 		webhookSwitches = admissioncmd.GardenWebhookSwitchOptions()
 		webhookOptions  = webhookcmd.NewAddToManagerOptions(
 			AdmissionName,
@@ -65,6 +67,7 @@ func NewAdmissionCommand(ctx context.Context) *cobra.Command {
 		aggOption = controllercmd.NewOptionAggregator(
 			restOpts,
 			mgrOpts,
+			configFileOpts,
 			webhookOptions,
 		)
 	)
@@ -146,6 +149,8 @@ func NewAdmissionCommand(ctx context.Context) *cobra.Command {
 				}
 			}
 
+			configFileOpts.Completed().ApplyAdmissionConfig(&mutator.DefaultAddOptions.AdmissionConfig)
+
 			log.Info("Setting up webhook server")
 			if _, err := webhookOptions.Completed().AddToManager(ctx, mgr, sourceCluster); err != nil {
 				return err
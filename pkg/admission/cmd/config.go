@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+	configloader "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/loader"
+	configvalidation "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/validation"
+
+	"github.com/spf13/pflag"
+)
+
+// AdmissionConfigOptions are command line options that can be set for config.AdmissionConfiguration.
+type AdmissionConfigOptions struct {
+	// ConfigFilePath is the path to the admission configuration file.
+	ConfigFilePath string
+
+	config *AdmissionConfig
+}
+
+// AdmissionConfig is a completed admission configuration.
+type AdmissionConfig struct {
+	// Config is the admission configuration.
+	Config *config.AdmissionConfiguration
+}
+
+func (c *AdmissionConfigOptions) buildConfig() (*config.AdmissionConfiguration, error) {
+	if len(c.ConfigFilePath) == 0 {
+		return configloader.LoadAdmissionConfiguration(nil)
+	}
+	return configloader.LoadAdmissionConfigurationFromFile(c.ConfigFilePath)
+}
+
+// Complete implements RESTCompleter.Complete.
+func (c *AdmissionConfigOptions) Complete() error {
+	config, err := c.buildConfig()
+	if err != nil {
+		return err
+	}
+
+	if errList := configvalidation.ValidateAdmissionConfiguration(config); len(errList) != 0 {
+		return errList.ToAggregate()
+	}
+
+	c.config = &AdmissionConfig{config}
+	return nil
+}
+
+// Completed returns the completed AdmissionConfig. Only call this if `Complete` was successful.
+func (c *AdmissionConfigOptions) Completed() *AdmissionConfig {
+	return c.config
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (c *AdmissionConfigOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.ConfigFilePath, "config-file", "", "path to the admission configuration file, the defaults are used if not set")
+}
+
+// ApplyAdmissionConfig sets the given admission configuration to that of this AdmissionConfig.
+func (c *AdmissionConfig) ApplyAdmissionConfig(admissionConfig *config.AdmissionConfiguration) {
+	*admissionConfig = *c.Config
+}
@@ -1,130 +1,154 @@
 package mutator
 
 import (
-	"os"
-	"strconv"
-	"strings"
-
 	calicoextensionv1alpha1 "github.com/gardener/gardener-extension-networking-calico/pkg/apis/calico/v1alpha1"
 	ciliumextensionv1alpha1 "github.com/gardener/gardener-extension-networking-cilium/pkg/apis/cilium/v1alpha1"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+
+	configapi "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
 )
 
-type config struct{}
+// config contains the shoot defaults which apply to a shoot, i.e. the configured shoot defaults with the overrides
+// for the cloud profile and the partition of the shoot applied.
+type config struct {
+	defaults configapi.ShootDefaults
+}
+
+func newConfig(cfg *configapi.AdmissionConfiguration, cloudProfile, partition string) *config {
+	defaults := cfg.ShootDefaults.DeepCopy()
+
+	for _, o := range cfg.ShootDefaultsOverrides {
+		if o.CloudProfile != "" && o.CloudProfile != cloudProfile {
+			continue
+		}
+		if o.Partition != "" && o.Partition != partition {
+			continue
+		}
+
+		overrideShootDefaults(defaults, o.ShootDefaults.DeepCopy())
+	}
+
+	return &config{defaults: *defaults}
+}
+
+// overrideShootDefaults overrides the defaults with all values which are set in the given override.
+func overrideShootDefaults(defaults, o *configapi.ShootDefaults) {
+	override(&defaults.MaxPods, o.MaxPods)
+	override(&defaults.NodeCIDRMaskSize, o.NodeCIDRMaskSize)
+	override(&defaults.PodsCIDR, o.PodsCIDR)
+	override(&defaults.ServicesCIDR, o.ServicesCIDR)
+	override(&defaults.NetworkType, o.NetworkType)
+
+	if o.Calico != nil {
+		if defaults.Calico == nil {
+			defaults.Calico = &configapi.CalicoDefaults{}
+		}
+		override(&defaults.Calico.Backend, o.Calico.Backend)
+		override(&defaults.Calico.KubeProxyEnabled, o.Calico.KubeProxyEnabled)
+		override(&defaults.Calico.PoolMode, o.Calico.PoolMode)
+		override(&defaults.Calico.TyphaEnabled, o.Calico.TyphaEnabled)
+	}
+
+	if o.Cilium != nil {
+		if defaults.Cilium == nil {
+			defaults.Cilium = &configapi.CiliumDefaults{}
+		}
+		override(&defaults.Cilium.HubbleEnabled, o.Cilium.HubbleEnabled)
+		override(&defaults.Cilium.KubeProxyEnabled, o.Cilium.KubeProxyEnabled)
+		override(&defaults.Cilium.TunnelMode, o.Cilium.TunnelMode)
+		if o.Cilium.Devices != nil {
+			defaults.Cilium.Devices = o.Cilium.Devices
+		}
+		override(&defaults.Cilium.DirectRoutingDevice, o.Cilium.DirectRoutingDevice)
+		override(&defaults.Cilium.BGPControlPlaneEnabled, o.Cilium.BGPControlPlaneEnabled)
+		override(&defaults.Cilium.IPv4NativeRoutingCIDREnabled, o.Cilium.IPv4NativeRoutingCIDREnabled)
+		override(&defaults.Cilium.LoadBalancingMode, o.Cilium.LoadBalancingMode)
+		override(&defaults.Cilium.MTU, o.Cilium.MTU)
+	}
+}
+
+func override[T any](value **T, o *T) {
+	if o != nil {
+		*value = o
+	}
+}
 
 func (c *config) maxPods() int32 {
-	return c.int32("DEFAULTER_MAXPODS", 250)
+	return pointer.SafeDeref(c.defaults.MaxPods)
 }
 
 func (c *config) nodeCIDRMaskSize() int32 {
-	return c.int32("DEFAULTER_NODECIDRMASKSIZE", 23)
+	return pointer.SafeDeref(c.defaults.NodeCIDRMaskSize)
 }
 
 func (c *config) podsCIDR() string {
-	return c.string("DEFAULTER_PODSCIDR", "10.240.0.0/13")
+	return pointer.SafeDeref(c.defaults.PodsCIDR)
 }
 
 func (c *config) servicesCIDR() string {
-	return c.string("DEFAULTER_SERVICESCIDR", "10.248.0.0/18")
+	return pointer.SafeDeref(c.defaults.ServicesCIDR)
 }
 
 func (c *config) networkType() string {
-	return c.string("DEFAULTER_NETWORKTYPE", "calico")
+	return pointer.SafeDeref(c.defaults.NetworkType)
+}
+
+func (c *config) calico() configapi.CalicoDefaults {
+	return pointer.SafeDeref(c.defaults.Calico)
 }
 
 func (c *config) calicoBackend() calicoextensionv1alpha1.Backend {
-	return calicoextensionv1alpha1.Backend(c.string("DEFAULTER_CALICOBACKEND", string(calicoextensionv1alpha1.None)))
+	return calicoextensionv1alpha1.Backend(pointer.SafeDeref(c.calico().Backend))
 }
 
 func (c *config) calicoKubeProxyEnabled() bool {
-	return c.bool("DEFAULTER_CALICOKUBEPROXYENABLED", true)
+	return pointer.SafeDeref(c.calico().KubeProxyEnabled)
 }
 
 func (c *config) calicoPoolMode() calicoextensionv1alpha1.IPv4PoolMode {
-	return calicoextensionv1alpha1.IPv4PoolMode(c.string("DEFAULTER_CALICOPOOLMODE", string(calicoextensionv1alpha1.Never)))
+	return calicoextensionv1alpha1.IPv4PoolMode(pointer.SafeDeref(c.calico().PoolMode))
 }
 
 func (c *config) calicoTyphaEnabled() bool {
-	return c.bool("DEFAULTER_CALICOTYPHAENABLED", false)
+	return pointer.SafeDeref(c.calico().TyphaEnabled)
+}
+
+func (c *config) cilium() configapi.CiliumDefaults {
+	return pointer.SafeDeref(c.defaults.Cilium)
 }
 
 func (c *config) ciliumHubbleEnabled() bool {
-	return c.bool("DEFAULTER_CILIUMHUBBLEENABLED", true)
+	return pointer.SafeDeref(c.cilium().HubbleEnabled)
 }
 
 func (c *config) ciliumKubeProxyEnabled() bool {
-	return c.bool("DEFAULTER_CILIUMKUBEPROXYENABLED", false)
+	return pointer.SafeDeref(c.cilium().KubeProxyEnabled)
 }
 
 func (c *config) ciliumTunnel() ciliumextensionv1alpha1.TunnelMode {
-	return ciliumextensionv1alpha1.TunnelMode(c.string("DEFAULTER_CILIUMTUNNEL", string(ciliumextensionv1alpha1.Disabled)))
+	return ciliumextensionv1alpha1.TunnelMode(pointer.SafeDeref(c.cilium().TunnelMode))
 }
 
 func (c *config) ciliumDevices() []string {
-	return c.slice("DEFAULTER_CILIUMDEVICES", []string{"lan+", "lo"})
+	return c.cilium().Devices
 }
 
 func (c *config) ciliumDirectRoutingDevice() string {
-	return c.string("DEFAULTER_CILIUMDIRECTROUTINGDEVICE", "lo")
+	return pointer.SafeDeref(c.cilium().DirectRoutingDevice)
 }
 
 func (c *config) bgpControlPlaneEnabled() bool {
-	return c.bool("DEFAULTER_CILIUMBGPCONTROLPLANE", true)
+	return pointer.SafeDeref(c.cilium().BGPControlPlaneEnabled)
 }
 
 func (c *config) ciliumIPv4NativeRoutingCIDREnabled() bool {
-	return c.bool("DEFAULTER_CILIUMIPV4NATIVEROUTINGCIDRENABLED", true)
+	return pointer.SafeDeref(c.cilium().IPv4NativeRoutingCIDREnabled)
 }
 
 func (c *config) ciliumLoadBalancingMode() ciliumextensionv1alpha1.LoadBalancingMode {
-	return ciliumextensionv1alpha1.LoadBalancingMode(c.string("DEFAULTER_CILIUMLOADBALANCINGMODE", string(ciliumextensionv1alpha1.DSR)))
+	return ciliumextensionv1alpha1.LoadBalancingMode(pointer.SafeDeref(c.cilium().LoadBalancingMode))
 }
 
 func (c *config) ciliumMTU() int {
-	return int(c.int32("DEFAULTER_CILIUMMTU", 1440))
-}
-
-func (c *config) bool(key string, fallback bool) bool {
-	value, ok := os.LookupEnv(key)
-	if !ok {
-		return fallback
-	}
-
-	parsed, err := strconv.ParseBool(value)
-	if err != nil {
-		return fallback
-	}
-
-	return parsed
-}
-
-func (c *config) string(key string, fallback string) string {
-	value, ok := os.LookupEnv(key)
-	if !ok {
-		return fallback
-	}
-
-	return value
-}
-
-func (c *config) slice(key string, fallback []string) []string {
-	value, ok := os.LookupEnv(key)
-	if !ok {
-		return fallback
-	}
-
-	return strings.Split(value, ",")
-}
-
-func (c *config) int32(key string, fallback int32) int32 {
-	value, ok := os.LookupEnv(key)
-	if !ok {
-		return fallback
-	}
-
-	parsed, err := strconv.ParseInt(value, 10, 32)
-	if err != nil {
-		return fallback
-	}
-
-	return int32(parsed) // nolint:gosec
+	return int(pointer.SafeDeref(c.cilium().MTU))
 }
@@ -0,0 +1,111 @@
+package mutator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configapi "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+)
+
+func Test_newConfig(t *testing.T) {
+	cfg := &configapi.AdmissionConfiguration{
+		ShootDefaults: configapi.ShootDefaults{
+			MaxPods:          new(int32(250)),
+			NodeCIDRMaskSize: new(int32(23)),
+			Cilium: &configapi.CiliumDefaults{
+				MTU:     new(int32(1440)),
+				Devices: []string{"lan+", "lo"},
+			},
+		},
+		ShootDefaultsOverrides: []configapi.ShootDefaultsOverride{
+			{
+				CloudProfile: "metal",
+				ShootDefaults: configapi.ShootDefaults{
+					NodeCIDRMaskSize: new(int32(24)),
+					Cilium: &configapi.CiliumDefaults{
+						MTU: new(int32(9000)),
+					},
+				},
+			},
+			{
+				CloudProfile: "metal",
+				Partition:    "partition-a",
+				ShootDefaults: configapi.ShootDefaults{
+					NodeCIDRMaskSize: new(int32(25)),
+				},
+			},
+			{
+				Partition: "partition-b",
+				ShootDefaults: configapi.ShootDefaults{
+					MaxPods: new(int32(110)),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		cloudProfile string
+		partition    string
+		want         configapi.ShootDefaults
+	}{
+		{
+			name:         "no override applies",
+			cloudProfile: "other",
+			partition:    "partition-a",
+			want:         cfg.ShootDefaults,
+		},
+		{
+			name:         "cloud profile override",
+			cloudProfile: "metal",
+			partition:    "partition-c",
+			want: configapi.ShootDefaults{
+				MaxPods:          new(int32(250)),
+				NodeCIDRMaskSize: new(int32(24)),
+				Cilium: &configapi.CiliumDefaults{
+					MTU:     new(int32(9000)),
+					Devices: []string{"lan+", "lo"},
+				},
+			},
+		},
+		{
+			name:         "later overrides take precedence",
+			cloudProfile: "metal",
+			partition:    "partition-a",
+			want: configapi.ShootDefaults{
+				MaxPods:          new(int32(250)),
+				NodeCIDRMaskSize: new(int32(25)),
+				Cilium: &configapi.CiliumDefaults{
+					MTU:     new(int32(9000)),
+					Devices: []string{"lan+", "lo"},
+				},
+			},
+		},
+		{
+			name:         "partition override of any cloud profile",
+			cloudProfile: "other",
+			partition:    "partition-b",
+			want: configapi.ShootDefaults{
+				MaxPods:          new(int32(110)),
+				NodeCIDRMaskSize: new(int32(23)),
+				Cilium: &configapi.CiliumDefaults{
+					MTU:     new(int32(1440)),
+					Devices: []string{"lan+", "lo"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newConfig(cfg, tt.cloudProfile, tt.partition)
+			if diff := cmp.Diff(tt.want, got.defaults); diff != "" {
+				t.Errorf("newConfig() diff = %s", diff)
+			}
+		})
+	}
+
+	if got := *cfg.ShootDefaults.NodeCIDRMaskSize; got != 23 {
+		t.Errorf("expected the admission configuration not to be modified, got node cidr mask size %d", got)
+	}
+}
@@ -2,6 +2,7 @@ package mutator
 
 import (
 	"errors"
+	"slices"
 	"sort"
 	"strings"
 
@@ -13,9 +14,14 @@ import (
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
+	metalvalidation "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
+	metalclient "github.com/metal-stack/gardener-extension-provider-metal/pkg/metal/client"
+	"github.com/metal-stack/metal-go/api/models"
 	"github.com/metal-stack/metal-lib/pkg/pointer"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type defaulter struct {
@@ -24,6 +30,9 @@ type defaulter struct {
 
 	controlPlane *metal.MetalControlPlane
 	partition    *metal.Partition
+	// networks are the networks of the metal-api, they are only looked up for new shoots which do not use a workload
+	// identity. The default external networks of the partition are validated against them.
+	networks map[string]*models.V1NetworkResponse
 }
 
 func (d *defaulter) defaultShoot(shoot *gardenv1beta1.Shoot) error {
@@ -63,12 +72,36 @@ func (d *defaulter) defaultInfrastructureConfig(shoot *gardenv1beta1.Shoot) erro
 		return err
 	}
 
+	defaulting := pointer.SafeDeref(d.partition.FirewallDefaulting)
+
 	if infrastructureConfig.Firewall.Image == "" {
-		infrastructureConfig.Firewall.Image = getLatestImage(d.controlPlane.FirewallImages)
+		infrastructureConfig.Firewall.Image = defaultFirewallImage(d.controlPlane, defaulting.ImageOS)
+	}
+
+	if infrastructureConfig.Firewall.Size == "" {
+		if defaulting.Size != nil {
+			infrastructureConfig.Firewall.Size = *defaulting.Size
+		} else if len(d.partition.FirewallTypes) > 0 {
+			infrastructureConfig.Firewall.Size = d.partition.FirewallTypes[0]
+		}
 	}
 
-	if infrastructureConfig.Firewall.Size == "" && len(d.partition.FirewallTypes) > 0 {
-		infrastructureConfig.Firewall.Size = d.partition.FirewallTypes[0]
+	if len(infrastructureConfig.Firewall.Networks) == 0 && len(d.partition.DefaultExternalNetworks) > 0 {
+		if d.networks != nil {
+			controlPlaneConfig := &metalv1alpha1.ControlPlaneConfig{}
+			err = helper.DecodeRawExtension(shoot.Spec.Provider.ControlPlaneConfig, controlPlaneConfig, d.decoder)
+			if err != nil {
+				return err
+			}
+
+			fldPath := field.NewPath("partitions").Key(infrastructureConfig.PartitionID).Child("defaultExternalNetworks")
+			networkAccessType := metal.NetworkAccessType(pointer.SafeDeref(controlPlaneConfig.NetworkAccessType))
+			if errList := metalvalidation.ValidateDefaultExternalNetworks(d.partition, networkAccessType, d.networks, fldPath); len(errList) != 0 {
+				return errList.ToAggregate()
+			}
+		}
+
+		infrastructureConfig.Firewall.Networks = slices.Clone(d.partition.DefaultExternalNetworks)
 	}
 
 	shoot.Spec.Provider.InfrastructureConfig = &runtime.RawExtension{
@@ -78,6 +111,62 @@ func (d *defaulter) defaultInfrastructureConfig(shoot *gardenv1beta1.Shoot) erro
 	return nil
 }
 
+// defaultControlPlaneConfig makes the choices explicit which are otherwise made implicitly when the control plane
+// is reconciled. The duros default storage class is the only choice left to the reconciliation.
+func (d *defaulter) defaultControlPlaneConfig(shoot *gardenv1beta1.Shoot) error {
+	infrastructureConfig := &metalv1alpha1.InfrastructureConfig{}
+	err := helper.DecodeRawExtension(shoot.Spec.Provider.InfrastructureConfig, infrastructureConfig, d.decoder)
+	if err != nil {
+		return err
+	}
+
+	controlPlaneConfig := &metalv1alpha1.ControlPlaneConfig{}
+	err = helper.DecodeRawExtension(shoot.Spec.Provider.ControlPlaneConfig, controlPlaneConfig, d.decoder)
+	if err != nil {
+		return err
+	}
+
+	controlPlaneConfig.TypeMeta = metav1.TypeMeta{
+		APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+		Kind:       "ControlPlaneConfig",
+	}
+
+	if controlPlaneConfig.NetworkAccessType == nil {
+		controlPlaneConfig.NetworkAccessType = new(metalv1alpha1.NetworkAccessBaseline)
+	}
+
+	// without csi-lvm, the default storage class is derived from the duros storage config of the partition when the
+	// control plane is reconciled, this config is only known to the extension running in the seed
+	if controlPlaneConfig.CustomDefaultStorageClass == nil && !pointer.SafeDeref(controlPlaneConfig.FeatureGates.DisableCsiLvm) {
+		controlPlaneConfig.CustomDefaultStorageClass = &metalv1alpha1.CustomDefaultStorageClass{
+			ClassName: "csi-lvm",
+		}
+	}
+
+	if d.networks != nil &&
+		*controlPlaneConfig.NetworkAccessType != metalv1alpha1.NetworkAccessForbidden &&
+		(controlPlaneConfig.CloudControllerManager == nil || controlPlaneConfig.CloudControllerManager.DefaultExternalNetwork == nil) {
+		// for isolated clusters with forbidden access type no default external network is used
+		networkID, err := metalclient.DefaultExternalNetwork(d.networks, infrastructureConfig.Firewall.Networks)
+		if err != nil {
+			return err
+		}
+
+		if networkID != "" {
+			if controlPlaneConfig.CloudControllerManager == nil {
+				controlPlaneConfig.CloudControllerManager = &metalv1alpha1.CloudControllerManagerConfig{}
+			}
+			controlPlaneConfig.CloudControllerManager.DefaultExternalNetwork = &networkID
+		}
+	}
+
+	shoot.Spec.Provider.ControlPlaneConfig = &runtime.RawExtension{
+		Object: controlPlaneConfig,
+	}
+
+	return nil
+}
+
 func (d *defaulter) defaultNetworking(shoot *gardenv1beta1.Shoot) error {
 	if len(shoot.Spec.Provider.Workers) == 0 {
 		// this is the workerless shoot case, don't default a network configuration
@@ -218,6 +307,30 @@ func (d *defaulter) defaultCiliumConfig(shoot *gardenv1beta1.Shoot) error {
 	return nil
 }
 
+// defaultFirewallImage returns the newest firewall image of the control plane which is neither a preview nor deprecated.
+// If an operating system is preferred, only images of this operating system are considered.
+func defaultFirewallImage(controlPlane *metal.MetalControlPlane, imageOS *string) string {
+	var candidates []string
+
+	for _, image := range controlPlane.FirewallImages {
+		switch controlPlane.FirewallImageClassifications[image] {
+		case metal.ClassificationPreview, metal.ClassificationDeprecated:
+			continue
+		}
+
+		if imageOS != nil {
+			os, _, err := getOsAndSemverFromImage(image)
+			if err != nil || os != *imageOS {
+				continue
+			}
+		}
+
+		candidates = append(candidates, image)
+	}
+
+	return getLatestImage(candidates)
+}
+
 func getLatestImage(images []string) string {
 	if len(images) < 1 {
 		return ""
@@ -12,10 +12,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 
+	configloader "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/loader"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/install"
 	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
+	"github.com/metal-stack/metal-go/api/models"
+	"github.com/metal-stack/metal-lib/pkg/tag"
 )
 
 func Test_defaulter_defaultShoot(t *testing.T) {
@@ -352,9 +355,13 @@ func Test_defaulter_defaultShoot(t *testing.T) {
 			},
 		},
 	}
+	admissionConfig, err := configloader.LoadAdmissionConfiguration(nil)
+	require.NoError(t, err)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &defaulter{
+				c:            newConfig(admissionConfig, "", "muc"),
 				decoder:      decoder,
 				controlPlane: exampleControlPlane,
 				partition:    examplePartition,
@@ -379,3 +386,283 @@ func mustEncode(t *testing.T, from runtime.Object) *runtime.RawExtension {
 	enc.Object = from
 	return enc
 }
+
+func Test_defaulter_defaultInfrastructureConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDecoder()
+
+	tests := []struct {
+		name                 string
+		controlPlane         *metal.MetalControlPlane
+		partition            *metal.Partition
+		infrastructureConfig *metalv1alpha1.InfrastructureConfig
+		controlPlaneConfig   *metalv1alpha1.ControlPlaneConfig
+		networks             map[string]*models.V1NetworkResponse
+		want                 metalv1alpha1.Firewall
+		wantErr              bool
+	}{
+		{
+			name: "preview and deprecated images are skipped",
+			controlPlane: &metal.MetalControlPlane{
+				FirewallImages: []string{
+					"firewall-ubuntu-3.0.20240101",
+					"firewall-ubuntu-3.0.20240201",
+					"firewall-ubuntu-3.0.20231201",
+					"firewall-2.0.20240301",
+				},
+				FirewallImageClassifications: map[string]metal.VersionClassification{
+					"firewall-ubuntu-3.0.20240201": metal.ClassificationPreview,
+					"firewall-2.0.20240301":        metal.ClassificationDeprecated,
+				},
+			},
+			partition: &metal.Partition{
+				FirewallTypes: []string{"n1-medium-x86", "c1-large-x86"},
+			},
+			want: metalv1alpha1.Firewall{
+				Image: "firewall-ubuntu-3.0.20240101",
+				Size:  "n1-medium-x86",
+			},
+		},
+		{
+			name: "policy of the partition is applied",
+			controlPlane: &metal.MetalControlPlane{
+				FirewallImages: []string{
+					"firewall-2.0.20240301",
+					"firewall-ubuntu-3.0.20240101",
+					"firewall-ubuntu-3.0.20231201",
+				},
+			},
+			partition: &metal.Partition{
+				FirewallTypes: []string{"n1-medium-x86", "c1-large-x86"},
+				FirewallDefaulting: &metal.FirewallDefaulting{
+					Size:    new("c1-large-x86"),
+					ImageOS: new("firewall-ubuntu"),
+				},
+			},
+			want: metalv1alpha1.Firewall{
+				Image: "firewall-ubuntu-3.0.20240101",
+				Size:  "c1-large-x86",
+			},
+		},
+		{
+			name: "no image is defaulted if none matches the policy",
+			controlPlane: &metal.MetalControlPlane{
+				FirewallImages: []string{
+					"firewall-ubuntu-3.0.20240101",
+				},
+				FirewallImageClassifications: map[string]metal.VersionClassification{
+					"firewall-ubuntu-3.0.20240101": metal.ClassificationPreview,
+				},
+			},
+			partition: &metal.Partition{},
+			want:      metalv1alpha1.Firewall{},
+		},
+		{
+			name:         "default external networks of the partition are added",
+			controlPlane: &metal.MetalControlPlane{},
+			partition: &metal.Partition{
+				DefaultExternalNetworks: []string{"internet"},
+			},
+			want: metalv1alpha1.Firewall{
+				Networks: []string{"internet"},
+			},
+		},
+		{
+			name:         "default external networks are validated against the networks of the metal-api",
+			controlPlane: &metal.MetalControlPlane{},
+			partition: &metal.Partition{
+				DefaultExternalNetworks: []string{"internet"},
+				NetworkIsolation: &metal.NetworkIsolation{
+					AllowedNetworks: metal.AllowedNetworks{Egress: []string{"100.127.0.0/16"}},
+				},
+			},
+			controlPlaneConfig: &metalv1alpha1.ControlPlaneConfig{
+				NetworkAccessType: new(metalv1alpha1.NetworkAccessRestricted),
+			},
+			networks: map[string]*models.V1NetworkResponse{
+				"internet": {ID: new("internet"), Destinationprefixes: []string{"0.0.0.0/0"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "default external networks must exist",
+			controlPlane: &metal.MetalControlPlane{},
+			partition: &metal.Partition{
+				DefaultExternalNetworks: []string{"internet"},
+			},
+			networks: map[string]*models.V1NetworkResponse{},
+			wantErr:  true,
+		},
+		{
+			name:         "firewall networks of the shoot are kept",
+			controlPlane: &metal.MetalControlPlane{},
+			partition: &metal.Partition{
+				DefaultExternalNetworks: []string{"internet"},
+			},
+			infrastructureConfig: &metalv1alpha1.InfrastructureConfig{
+				Firewall: metalv1alpha1.Firewall{
+					Networks: []string{"mpls"},
+				},
+			},
+			want: metalv1alpha1.Firewall{
+				Networks: []string{"mpls"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &defaulter{
+				decoder:      decoder,
+				controlPlane: tt.controlPlane,
+				partition:    tt.partition,
+				networks:     tt.networks,
+			}
+
+			shoot := &gardenv1beta1.Shoot{}
+			if tt.infrastructureConfig != nil {
+				shoot.Spec.Provider.InfrastructureConfig = mustEncode(t, tt.infrastructureConfig)
+			}
+			if tt.controlPlaneConfig != nil {
+				shoot.Spec.Provider.ControlPlaneConfig = mustEncode(t, tt.controlPlaneConfig)
+			}
+
+			err := d.defaultInfrastructureConfig(shoot)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := shoot.Spec.Provider.InfrastructureConfig.Object.(*metalv1alpha1.InfrastructureConfig).Firewall
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_defaulter_defaultControlPlaneConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDecoder()
+
+	networks := map[string]*models.V1NetworkResponse{
+		"internet": {
+			ID:     new("internet"),
+			Labels: map[string]string{tag.NetworkDefaultExternal: "", tag.NetworkDefault: ""},
+		},
+		"mpls": {
+			ID:     new("mpls"),
+			Labels: map[string]string{tag.NetworkDefaultExternal: ""},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		networks           map[string]*models.V1NetworkResponse
+		firewallNetworks   []string
+		controlPlaneConfig *metalv1alpha1.ControlPlaneConfig
+		want               *metalv1alpha1.ControlPlaneConfig
+		wantErr            bool
+	}{
+		{
+			name:               "empty control plane config",
+			controlPlaneConfig: &metalv1alpha1.ControlPlaneConfig{},
+			want: &metalv1alpha1.ControlPlaneConfig{
+				NetworkAccessType:         new(metalv1alpha1.NetworkAccessBaseline),
+				CustomDefaultStorageClass: &metalv1alpha1.CustomDefaultStorageClass{ClassName: "csi-lvm"},
+			},
+		},
+		{
+			name: "storage class is left to the duros storage config when csi-lvm is disabled",
+			controlPlaneConfig: &metalv1alpha1.ControlPlaneConfig{
+				FeatureGates: metalv1alpha1.ControlPlaneFeatures{DisableCsiLvm: new(true)},
+			},
+			want: &metalv1alpha1.ControlPlaneConfig{
+				FeatureGates:      metalv1alpha1.ControlPlaneFeatures{DisableCsiLvm: new(true)},
+				NetworkAccessType: new(metalv1alpha1.NetworkAccessBaseline),
+			},
+		},
+		{
+			name: "explicit values are kept",
+			controlPlaneConfig: &metalv1alpha1.ControlPlaneConfig{
+				NetworkAccessType:         new(metalv1alpha1.NetworkAccessRestricted),
+				CustomDefaultStorageClass: &metalv1alpha1.CustomDefaultStorageClass{ClassName: "premium"},
+				CloudControllerManager:    &metalv1alpha1.CloudControllerManagerConfig{DefaultExternalNetwork: new("mpls")},
+			},
+			networks:         networks,
+			firewallNetworks: []string{"internet", "mpls"},
+			want: &metalv1alpha1.ControlPlaneConfig{
+				NetworkAccessType:         new(metalv1alpha1.NetworkAccessRestricted),
+				CustomDefaultStorageClass: &metalv1alpha1.CustomDefaultStorageClass{ClassName: "premium"},
+				CloudControllerManager:    &metalv1alpha1.CloudControllerManagerConfig{DefaultExternalNetwork: new("mpls")},
+			},
+		},
+		{
+			name:               "default external network is derived from the firewall networks",
+			controlPlaneConfig: &metalv1alpha1.ControlPlaneConfig{},
+			networks:           networks,
+			firewallNetworks:   []string{"mpls", "internet"},
+			want: &metalv1alpha1.ControlPlaneConfig{
+				NetworkAccessType:         new(metalv1alpha1.NetworkAccessBaseline),
+				CustomDefaultStorageClass: &metalv1alpha1.CustomDefaultStorageClass{ClassName: "csi-lvm"},
+				CloudControllerManager:    &metalv1alpha1.CloudControllerManagerConfig{DefaultExternalNetwork: new("internet")},
+			},
+		},
+		{
+			name: "no default external network for forbidden access type",
+			controlPlaneConfig: &metalv1alpha1.ControlPlaneConfig{
+				NetworkAccessType: new(metalv1alpha1.NetworkAccessForbidden),
+			},
+			networks:         networks,
+			firewallNetworks: []string{"internet"},
+			want: &metalv1alpha1.ControlPlaneConfig{
+				NetworkAccessType:         new(metalv1alpha1.NetworkAccessForbidden),
+				CustomDefaultStorageClass: &metalv1alpha1.CustomDefaultStorageClass{ClassName: "csi-lvm"},
+			},
+		},
+		{
+			name:               "unknown firewall network",
+			controlPlaneConfig: &metalv1alpha1.ControlPlaneConfig{},
+			networks:           networks,
+			firewallNetworks:   []string{"unknown"},
+			wantErr:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &defaulter{
+				c:        &config{},
+				decoder:  decoder,
+				networks: tt.networks,
+			}
+
+			shoot := &gardenv1beta1.Shoot{
+				Spec: gardenv1beta1.ShootSpec{
+					Provider: gardenv1beta1.Provider{
+						InfrastructureConfig: mustEncode(t, &metalv1alpha1.InfrastructureConfig{
+							Firewall: metalv1alpha1.Firewall{Networks: tt.firewallNetworks},
+						}),
+						ControlPlaneConfig: mustEncode(t, tt.controlPlaneConfig),
+					},
+				},
+			}
+
+			err := d.defaultControlPlaneConfig(shoot)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("defaultControlPlaneConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := shoot.Spec.Provider.ControlPlaneConfig.Object.(*metalv1alpha1.ControlPlaneConfig)
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(metalv1alpha1.ControlPlaneConfig{}, "TypeMeta")); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
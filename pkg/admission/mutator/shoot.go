@@ -2,15 +2,20 @@ package mutator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/gardener/gardener/pkg/utils/gardener"
 
+	configapi "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
+	metalclient "github.com/metal-stack/gardener-extension-provider-metal/pkg/metal/client"
+	"github.com/metal-stack/metal-go/api/client/network"
+	"github.com/metal-stack/metal-go/api/models"
 
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 
@@ -22,14 +27,18 @@ import (
 // NewShootMutator returns a new instance of a shoot mutator.
 func NewShootMutator(mgr manager.Manager) extensionswebhook.Mutator {
 	return &mutator{
-		client:  mgr.GetClient(),
-		decoder: serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		client:          mgr.GetClient(),
+		apiReader:       mgr.GetAPIReader(),
+		decoder:         serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		admissionConfig: DefaultAddOptions.AdmissionConfig.DeepCopy(),
 	}
 }
 
 type mutator struct {
-	client  client.Client
-	decoder runtime.Decoder
+	client          client.Client
+	apiReader       client.Reader
+	decoder         runtime.Decoder
+	admissionConfig *configapi.AdmissionConfiguration
 }
 
 // Mutate mutates the given shoot object.
@@ -39,6 +48,11 @@ func (m *mutator) Mutate(ctx context.Context, new, old client.Object) error {
 		return fmt.Errorf("wrong object type %T", new)
 	}
 
+	if len(shoot.Spec.Provider.Workers) == 0 {
+		// workerless shoots have no infrastructure and no nodes, there is nothing to default for them
+		return nil
+	}
+
 	profile, err := gardener.GetCloudProfile(ctx, m.client, shoot)
 	if err != nil {
 		return err
@@ -62,11 +76,58 @@ func (m *mutator) Mutate(ctx context.Context, new, old client.Object) error {
 	}
 
 	d := defaulter{
-		c:            &config{},
+		c:            newConfig(m.admissionConfig, profile.Name, infrastructureConfig.PartitionID),
 		decoder:      m.decoder,
 		controlPlane: controlPlane,
 		partition:    partition,
 	}
 
-	return d.defaultShoot(shoot)
+	if old == nil {
+		d.networks, err = m.listNetworks(ctx, shoot, controlPlane)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = d.defaultShoot(shoot)
+	if err != nil {
+		return err
+	}
+
+	if old != nil {
+		// the control plane config of existing shoots is not defaulted as this could change decisions
+		// which were made implicitly when the control plane was reconciled before
+		return nil
+	}
+
+	return d.defaultControlPlaneConfig(shoot)
+}
+
+// listNetworks returns the networks of the metal-api, nil is returned if the shoot uses a workload identity
+// as the admission cannot use it for accessing the metal-api.
+func (m *mutator) listNetworks(ctx context.Context, shoot *gardenv1beta1.Shoot, controlPlane *metal.MetalControlPlane) (map[string]*models.V1NetworkResponse, error) {
+	credentials, err := metalclient.ReadCredentialsFromBinding(ctx, m.apiReader, shoot.Namespace, shoot.Spec.CredentialsBindingName, shoot.Spec.SecretBindingName) //nolint:staticcheck
+	if errors.Is(err, metalclient.ErrWorkloadIdentity) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mclient, err := metalclient.NewClientFromCredentials(controlPlane.Endpoint, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := mclient.Network().ListNetworks(network.NewListNetworksParams().WithContext(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve networks from metal-api %w", err)
+	}
+
+	networks := map[string]*models.V1NetworkResponse{}
+	for _, n := range resp.Payload {
+		networks[*n.ID] = n
+	}
+
+	return networks, nil
 }
@@ -3,6 +3,7 @@ package mutator
 import (
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	configapi "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -14,7 +15,18 @@ const (
 	Name = "mutator"
 )
 
-var logger = log.Log.WithName("metal-mutator-webhook")
+var (
+	logger = log.Log.WithName("metal-mutator-webhook")
+
+	// DefaultAddOptions are the default AddOptions for New.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are options to apply when adding the mutator webhook.
+type AddOptions struct {
+	// AdmissionConfig contains the shoot defaults the shoots are mutated with.
+	AdmissionConfig configapi.AdmissionConfiguration
+}
 
 // New creates a new webhook that mutates Shoot resources.
 func New(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
@@ -8,6 +8,7 @@ import (
 	"github.com/gardener/gardener/pkg/apis/core"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
 	metalvalidation "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -39,13 +40,20 @@ func (cp *cloudProfile) Validate(_ context.Context, new, old client.Object) erro
 		return field.Required(providerConfigPath, "providerConfig must be set for metal cloud profiles")
 	}
 
+	versionedCpConfig := &metalv1alpha1.CloudProfileConfig{}
+	err := helper.DecodeRawExtension(cloudProfile.Spec.ProviderConfig, versionedCpConfig, cp.decoder)
+	if err != nil {
+		return err
+	}
+
 	cpConfig := &metal.CloudProfileConfig{}
-	err := helper.DecodeRawExtension(cloudProfile.Spec.ProviderConfig, cpConfig, cp.decoder)
+	err = helper.DecodeRawExtension(cloudProfile.Spec.ProviderConfig, cpConfig, cp.decoder)
 	if err != nil {
 		return err
 	}
 
-	errs := metalvalidation.ValidateCloudProfileConfig(cpConfig, cloudProfile, providerConfigPath)
+	errs := metalvalidation.ValidateCloudProfileConfigNetworkServers(versionedCpConfig, providerConfigPath)
+	errs = append(errs, metalvalidation.ValidateCloudProfileConfig(cpConfig, cloudProfile, providerConfigPath)...)
 	if old == nil {
 		return errs.ToAggregate()
 	}
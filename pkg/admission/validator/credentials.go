@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils/gardener"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	metalclient "github.com/metal-stack/gardener-extension-provider-metal/pkg/metal/client"
+)
+
+// validateCredentialsAgainstMetalAPI looks up the projects of the given shoots with the credentials of the given secret
+// in the metal control planes of their partitions, which enable the validation of credentials. Bindings do not reference
+// a cloud profile, so the credentials are only validated against the metal control planes of the shoots which use the
+// binding. The credentials of bindings which are not used by any shoot yet are validated on the admission of the first
+// shoot instead. The cloud profiles are read from the cache of the given client.
+func validateCredentialsAgainstMetalAPI(ctx context.Context, c client.Reader, shoots []gardencorev1beta1.Shoot, secret *corev1.Secret) error {
+	var (
+		credentials *metal.Credentials
+		validated   = sets.New[string]()
+		errs        []error
+	)
+
+	for _, shoot := range shoots {
+		if shoot.Spec.Provider.Type != metal.Type || shoot.Spec.Provider.InfrastructureConfig == nil {
+			// workerless shoots do not have a partition and do not use the credentials against the metal-api
+			continue
+		}
+
+		cloudProfile, err := gardener.GetCloudProfile(ctx, c, &shoot)
+		if err != nil {
+			return fmt.Errorf("unable to determine the cloud profile of shoot %q which uses the binding: %w", shoot.Name, err)
+		}
+
+		cloudProfileConfig, err := helper.DecodeCloudProfileConfig(cloudProfile)
+		if err != nil {
+			return err
+		}
+		if cloudProfileConfig == nil {
+			return fmt.Errorf("cloud profile %q of shoot %q which uses the binding has no provider config", cloudProfile.Name, shoot.Name)
+		}
+
+		infrastructureConfig, err := helper.InfrastructureConfigFromShoot(&shoot)
+		if err != nil {
+			return fmt.Errorf("unable to decode the infrastructure config of shoot %q which uses the binding: %w", shoot.Name, err)
+		}
+
+		mcp, _, err := helper.FindMetalControlPlane(cloudProfileConfig, infrastructureConfig.PartitionID)
+		if err != nil {
+			return fmt.Errorf("unable to determine the metal control plane of shoot %q which uses the binding: %w", shoot.Name, err)
+		}
+
+		if !pointer.SafeDeref(mcp.ValidateCredentials) || validated.Has(mcp.Endpoint+"/"+infrastructureConfig.ProjectID) {
+			continue
+		}
+		validated.Insert(mcp.Endpoint + "/" + infrastructureConfig.ProjectID)
+
+		if credentials == nil {
+			credentials, err = metal.ReadCredentialsSecret(secret)
+			if err != nil {
+				return err
+			}
+		}
+
+		mclient, err := metalclient.NewClientFromCredentials(mcp.Endpoint, credentials)
+		if err != nil {
+			return err
+		}
+
+		if err := metalclient.VerifyCredentials(ctx, mclient, infrastructureConfig.ProjectID); err != nil {
+			errs = append(errs, fmt.Errorf("shoot %q in partition %q of cloud profile %q: %w", shoot.Name, infrastructureConfig.PartitionID, cloudProfile.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("credentials are not valid for the shoots which use the binding: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// shootsUsingBinding returns the shoots in the given namespace which use a binding according to the given function. The
+// shoots are read with the given api reader, such that no informer for shoots is started.
+func shootsUsingBinding(ctx context.Context, apiReader client.Reader, namespace string, usesBinding func(*gardencorev1beta1.Shoot) bool) ([]gardencorev1beta1.Shoot, error) {
+	shoots := &gardencorev1beta1.ShootList{}
+	if err := apiReader.List(ctx, shoots, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(shoots.Items, func(shoot gardencorev1beta1.Shoot) bool {
+		return !usesBinding(&shoot)
+	}), nil
+}
+
+// validateProject checks that the project of the shoot exists, that it belongs to the tenant the shoot is annotated
+// with and that the credentials of the shoot have access to it. The lookup is only carried out when the validation of
+// credentials is enabled for the metal control plane.
+func (s *shoot) validateProject(ctx context.Context, shoot *core.Shoot, infraConfig *apismetal.InfrastructureConfig, cloudProfileConfig *apismetal.CloudProfileConfig, fldPath *field.Path) error {
+	if cloudProfileConfig == nil {
+		return nil
+	}
+
+	mcp, _, err := helper.FindMetalControlPlane(cloudProfileConfig, infraConfig.PartitionID)
+	if err != nil {
+		return err
+	}
+
+	if !pointer.SafeDeref(mcp.ValidateCredentials) {
+		return nil
+	}
+
+	credentials, err := s.readShootCredentials(ctx, shoot)
+	if errors.Is(err, metalclient.ErrWorkloadIdentity) {
+		// documented on MetalControlPlane.ValidateCredentials, the project of shoots with workload identities cannot
+		// be looked up during admission
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read metal-api credentials of shoot: %w", err)
+	}
+
+	mclient, err := metalclient.NewClientFromCredentials(mcp.Endpoint, credentials)
+	if err != nil {
+		return err
+	}
+
+	if err := metalclient.VerifyProjectOwnership(ctx, mclient, infraConfig.ProjectID, shoot.Annotations[tag.ClusterTenant]); err != nil {
+		return field.Invalid(fldPath.Child("projectID"), infraConfig.ProjectID, err.Error())
+	}
+
+	return nil
+}
@@ -5,26 +5,32 @@ import (
 	"fmt"
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/gardener/gardener/pkg/apis/security"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	metalvalidation "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
 )
 
 type credentialsBinding struct {
+	client    client.Client
 	apiReader client.Reader
 }
 
 // NewCredentialsBindingValidator returns a new instance of a credentials binding validator.
 func NewCredentialsBindingValidator(mgr manager.Manager) extensionswebhook.Validator {
 	return &credentialsBinding{
+		client:    mgr.GetClient(),
 		apiReader: mgr.GetAPIReader(),
 	}
 }
 
-// Validate checks whether the given CredentialsBinding refers to valid GCP credentials.
+// Validate checks whether the given CredentialsBinding refers to valid metal credentials.
 func (cb *credentialsBinding) Validate(ctx context.Context, newObj, oldObj client.Object) error {
 	credentialsBinding, ok := newObj.(*security.CredentialsBinding)
 	if !ok {
@@ -54,6 +60,28 @@ func (cb *credentialsBinding) Validate(ctx context.Context, newObj, oldObj clien
 		if err := metalvalidation.ValidateCloudProviderSecret(secret); err != nil {
 			return fmt.Errorf("referenced secret %s is not valid: %w", credentialsKey, err)
 		}
+
+		shoots, err := shootsUsingBinding(ctx, cb.apiReader, credentialsBinding.Namespace, func(shoot *gardencorev1beta1.Shoot) bool {
+			return pointer.SafeDeref(shoot.Spec.CredentialsBindingName) == credentialsBinding.Name
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := validateCredentialsAgainstMetalAPI(ctx, cb.client, shoots, secret); err != nil {
+			return fmt.Errorf("referenced secret %s is not valid: %w", credentialsKey, err)
+		}
+		return nil
+
+	case credentialsBinding.CredentialsRef.APIVersion == securityv1alpha1.SchemeGroupVersion.String() && credentialsBinding.CredentialsRef.Kind == "WorkloadIdentity":
+		workloadIdentity := &securityv1alpha1.WorkloadIdentity{}
+		if err := cb.apiReader.Get(ctx, credentialsKey, workloadIdentity); err != nil {
+			return err
+		}
+
+		if workloadIdentity.Spec.TargetSystem.Type != metal.Type {
+			return fmt.Errorf("referenced workload identity %s is not of type %q", credentialsKey, metal.Type)
+		}
 		return nil
 
 	default:
@@ -6,23 +6,30 @@ import (
 	"fmt"
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/gardener/gardener/pkg/apis/security"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	mockclient "github.com/gardener/gardener/third_party/mock/controller-runtime/client"
 	mockmanager "github.com/gardener/gardener/third_party/mock/controller-runtime/manager"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/admission/validator"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var _ = Describe("CredentialsBinding validator", func() {
 	Describe("#Validate", func() {
 		const (
-			namespace = "garden-dev"
-			name      = "my-provider-account"
+			namespace   = "garden-dev"
+			name        = "my-provider-account"
+			bindingName = "my-binding"
 		)
 
 		var (
@@ -31,6 +38,7 @@ var _ = Describe("CredentialsBinding validator", func() {
 			ctrl      *gomock.Controller
 			mgr       *mockmanager.MockManager
 			apiReader *mockclient.MockReader
+			c         client.Client
 
 			ctx                      = context.TODO()
 			credentialsBindingSecret *security.CredentialsBinding
@@ -46,11 +54,17 @@ var _ = Describe("CredentialsBinding validator", func() {
 			apiReader = mockclient.NewMockReader(ctrl)
 			mgr.EXPECT().GetAPIReader().Return(apiReader)
 
+			scheme := runtime.NewScheme()
+			Expect(gardencorev1beta1.AddToScheme(scheme)).To(Succeed())
+			c = fakeclient.NewClientBuilder().WithScheme(scheme).Build()
+			mgr.EXPECT().GetClient().Return(c)
+
 			credentialsBindingValidator = validator.NewCredentialsBindingValidator(
 				mgr,
 			)
 
 			credentialsBindingSecret = &security.CredentialsBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: bindingName, Namespace: namespace},
 				CredentialsRef: corev1.ObjectReference{
 					Name:       name,
 					Namespace:  namespace,
@@ -115,17 +129,117 @@ var _ = Describe("CredentialsBinding validator", func() {
 			Expect(err).To(MatchError("referenced secret garden-dev/my-provider-account is not valid: either hmac or api key must be set"))
 		})
 
-		It("should succeed when the corresponding Secret is valid", func() {
-			apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&corev1.Secret{})).
-				DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret, _ ...client.GetOption) error {
-					secret := &corev1.Secret{Data: map[string][]byte{
-						metal.APIHMac: []byte(`a-secure-secret`),
-					}}
-					*obj = *secret
-					return nil
-				})
+		Context("online validation", func() {
+			var shoots []gardencorev1beta1.Shoot
+
+			BeforeEach(func() {
+				apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&corev1.Secret{})).
+					DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret, _ ...client.GetOption) error {
+						secret := &corev1.Secret{Data: map[string][]byte{
+							metal.APIHMac: []byte(`a-secure-secret`),
+						}}
+						*obj = *secret
+						return nil
+					})
+
+				shoots = nil
+			})
+
+			expectShoots := func(err error) {
+				apiReader.EXPECT().List(ctx, gomock.AssignableToTypeOf(&gardencorev1beta1.ShootList{}), client.InNamespace(namespace)).
+					DoAndReturn(func(_ context.Context, list *gardencorev1beta1.ShootList, _ ...client.ListOption) error {
+						list.Items = shoots
+						return err
+					})
+			}
+
+			It("should succeed when the binding is not used by any shoot", func() {
+				expectShoots(nil)
+
+				Expect(credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)).To(Succeed())
+			})
+
+			It("should not look up the credentials when the validation is disabled in the cloud profile of the shoot", func() {
+				Expect(c.Create(ctx, &gardencorev1beta1.NamespacedCloudProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "metal", Namespace: namespace},
+					Status: gardencorev1beta1.NamespacedCloudProfileStatus{
+						CloudProfileSpec: gardencorev1beta1.CloudProfileSpec{
+							Type: metal.Type,
+							ProviderConfig: &runtime.RawExtension{
+								Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","metalControlPlanes":{"prod":{"endpoint":"https://metal-api.invalid","validateCredentials":false,"partitions":{"partition-a":{}}}}}`),
+							},
+						},
+					},
+				})).To(Succeed())
+				shoot := newShootUsingBinding("shoot-a", "metal", nil, pointer.Pointer(bindingName))
+				shoot.Spec.CloudProfile.Kind = "NamespacedCloudProfile"
+				shoots = []gardencorev1beta1.Shoot{
+					shoot,
+					newShootUsingBinding("shoot-b", "unknown", nil, pointer.Pointer("other-binding")),
+				}
+				expectShoots(nil)
+
+				Expect(credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)).To(Succeed())
+			})
+
+			It("should return err if the partition of a shoot which uses the binding is not in its cloud profile", func() {
+				Expect(c.Create(ctx, &gardencorev1beta1.CloudProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "metal"},
+					Spec: gardencorev1beta1.CloudProfileSpec{
+						Type: metal.Type,
+						ProviderConfig: &runtime.RawExtension{
+							Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","metalControlPlanes":{"prod":{"endpoint":"https://metal-api.invalid","validateCredentials":true,"partitions":{"partition-b":{}}}}}`),
+						},
+					},
+				})).To(Succeed())
+				shoots = []gardencorev1beta1.Shoot{newShootUsingBinding("shoot-a", "metal", nil, pointer.Pointer(bindingName))}
+				expectShoots(nil)
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)
+				Expect(err).To(MatchError(ContainSubstring(`unable to determine the metal control plane of shoot "shoot-a" which uses the binding`)))
+			})
+
+			It("should return err if it fails to list the shoots", func() {
+				expectShoots(fakeErr)
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
 
-			Expect(credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)).To(Succeed())
+		Context("workload identity", func() {
+			BeforeEach(func() {
+				credentialsBindingSecret.CredentialsRef.APIVersion = "security.gardener.cloud/v1alpha1"
+				credentialsBindingSecret.CredentialsRef.Kind = "WorkloadIdentity"
+			})
+
+			It("should return err if it fails to get the corresponding WorkloadIdentity", func() {
+				apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&securityv1alpha1.WorkloadIdentity{})).Return(fakeErr)
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)
+				Expect(err).To(MatchError(fakeErr))
+			})
+
+			It("should return err when the WorkloadIdentity targets another provider", func() {
+				apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&securityv1alpha1.WorkloadIdentity{})).
+					DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *securityv1alpha1.WorkloadIdentity, _ ...client.GetOption) error {
+						obj.Spec.TargetSystem.Type = "aws"
+						return nil
+					})
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)
+				Expect(err).To(MatchError(`referenced workload identity garden-dev/my-provider-account is not of type "metal"`))
+			})
+
+			It("should succeed when the WorkloadIdentity targets metal", func() {
+				apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&securityv1alpha1.WorkloadIdentity{})).
+					DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *securityv1alpha1.WorkloadIdentity, _ ...client.GetOption) error {
+						obj.Spec.TargetSystem.Type = metal.Type
+						return nil
+					})
+
+				Expect(credentialsBindingValidator.Validate(ctx, credentialsBindingSecret, nil)).To(Succeed())
+			})
 		})
 
 		It("should return nil when the CredentialsBinding did not change", func() {
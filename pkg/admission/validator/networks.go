@@ -0,0 +1,237 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	metalgo "github.com/metal-stack/metal-go"
+	metalip "github.com/metal-stack/metal-go/api/client/ip"
+	"github.com/metal-stack/metal-go/api/client/network"
+	"github.com/metal-stack/metal-go/api/models"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	metalvalidation "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	metalclient "github.com/metal-stack/gardener-extension-provider-metal/pkg/metal/client"
+)
+
+// validateFirewallNetworks looks up the networks referenced by the firewall egress rules and rate limits in the metal-api
+// and validates them against these networks. The lookup is only carried out when it is enabled for the metal control plane.
+func (s *shoot) validateFirewallNetworks(ctx context.Context, shoot *core.Shoot, infraConfig *apismetal.InfrastructureConfig, cloudProfileConfig *apismetal.CloudProfileConfig, fldPath *field.Path) error {
+	if cloudProfileConfig == nil || (len(infraConfig.Firewall.EgressRules) == 0 && len(infraConfig.Firewall.RateLimits) == 0) {
+		return nil
+	}
+
+	mcp, _, err := helper.FindMetalControlPlane(cloudProfileConfig, infraConfig.PartitionID)
+	if err != nil {
+		return err
+	}
+
+	if !pointer.SafeDeref(mcp.ValidateNetworks) {
+		return nil
+	}
+
+	credentials, err := s.readShootCredentials(ctx, shoot)
+	if errors.Is(err, metalclient.ErrWorkloadIdentity) {
+		// documented on MetalControlPlane.ValidateNetworks, the firewall networks of shoots with workload identities
+		// cannot be looked up during admission
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read metal-api credentials of shoot: %w", err)
+	}
+
+	mclient, err := metalclient.NewClientFromCredentials(mcp.Endpoint, credentials)
+	if err != nil {
+		return err
+	}
+
+	networks, err := findNetworks(ctx, mclient, referencedFirewallNetworks(infraConfig))
+	if err != nil {
+		return err
+	}
+
+	if errList := metalvalidation.ValidateInfrastructureConfigAgainstNetworks(infraConfig, networks, fldPath); len(errList) != 0 {
+		return errList.ToAggregate()
+	}
+
+	return nil
+}
+
+// referencedFirewallNetworks returns the ids of the networks referenced by the egress rules and rate limits of the firewall.
+func referencedFirewallNetworks(infraConfig *apismetal.InfrastructureConfig) []string {
+	var ids []string
+	for _, egress := range infraConfig.Firewall.EgressRules {
+		ids = append(ids, egress.NetworkID)
+	}
+	for _, rateLimit := range infraConfig.Firewall.RateLimits {
+		ids = append(ids, rateLimit.NetworkID)
+	}
+
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// validateWorkerNetworks looks up the additional networks of the worker pools in the metal-api and validates them
+// against these networks. The worker configs are indexed like the workers of the shoot, workers without a provider
+// config have no entry. Clusters with a restricted or forbidden network access type must not use additional networks
+// if the lookup is not enabled for the metal control plane, as the networks would bypass the firewall unnoticed.
+func (s *shoot) validateWorkerNetworks(ctx context.Context, shoot *core.Shoot, workerConfigs []*apismetal.WorkerConfig, controlPlaneConfig *apismetal.ControlPlaneConfig, infraConfig *apismetal.InfrastructureConfig, cloudProfileConfig *apismetal.CloudProfileConfig, fldPath *field.Path) error {
+	var ids []string
+	for _, workerConfig := range workerConfigs {
+		if workerConfig != nil {
+			ids = append(ids, workerConfig.AdditionalNetworks...)
+		}
+	}
+	if cloudProfileConfig == nil || len(ids) == 0 {
+		return nil
+	}
+
+	mcp, partition, err := helper.FindMetalControlPlane(cloudProfileConfig, infraConfig.PartitionID)
+	if err != nil {
+		return err
+	}
+
+	isolated := controlPlaneConfig.NetworkAccessType != nil && *controlPlaneConfig.NetworkAccessType != apismetal.NetworkAccessBaseline
+
+	var credentials *metal.Credentials
+	if pointer.SafeDeref(mcp.ValidateNetworks) {
+		credentials, err = s.readShootCredentials(ctx, shoot)
+		if err != nil && !errors.Is(err, metalclient.ErrWorkloadIdentity) {
+			return fmt.Errorf("unable to read metal-api credentials of shoot: %w", err)
+		}
+	}
+
+	if credentials == nil {
+		if !isolated {
+			return nil
+		}
+
+		allErrs := field.ErrorList{}
+		for i, workerConfig := range workerConfigs {
+			if workerConfig != nil && len(workerConfig.AdditionalNetworks) > 0 {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("workers").Index(i).Child("providerConfig", "additionalNetworks"), "additional networks of clusters with a restricted or forbidden network access type can only be used when the networks are validated against the metal-api"))
+			}
+		}
+		return allErrs.ToAggregate()
+	}
+
+	mclient, err := metalclient.NewClientFromCredentials(mcp.Endpoint, credentials)
+	if err != nil {
+		return err
+	}
+
+	slices.Sort(ids)
+	networks, err := findNetworks(ctx, mclient, slices.Compact(ids))
+	if err != nil {
+		return err
+	}
+
+	allErrs := field.ErrorList{}
+	for i, workerConfig := range workerConfigs {
+		if workerConfig == nil {
+			continue
+		}
+		allErrs = append(allErrs, metalvalidation.ValidateWorkerConfigAgainstNetworks(workerConfig, controlPlaneConfig, partition, networks, fldPath.Child("workers").Index(i).Child("providerConfig"))...)
+	}
+
+	return allErrs.ToAggregate()
+}
+
+// findNetworks looks up the networks with the given ids in the metal-api. Only the referenced networks are looked up,
+// listing all networks of the metal-api is too expensive for every admission.
+func findNetworks(ctx context.Context, mclient metalgo.Client, ids []string) (map[string]*models.V1NetworkResponse, error) {
+	networks := map[string]*models.V1NetworkResponse{}
+	for _, id := range ids {
+		resp, err := mclient.Network().FindNetworks(network.NewFindNetworksParams().WithBody(&models.V1NetworkFindRequest{
+			ID: id,
+		}).WithContext(ctx), nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find network %q in metal-api: %w", id, err)
+		}
+
+		for _, nw := range resp.Payload {
+			networks[*nw.ID] = nw
+		}
+	}
+
+	return networks, nil
+}
+
+// validateNetworkAccessMigration rejects a migration to the forbidden network access type while ips of load balancers of
+// the cluster are not contained in the allowed ingress networks, as the firewall would drop the traffic to them.
+func (s *shoot) validateNetworkAccessMigration(ctx context.Context, shoot *core.Shoot, oldConfig, newConfig *apismetal.ControlPlaneConfig, infraConfig *apismetal.InfrastructureConfig, cloudProfileConfig *apismetal.CloudProfileConfig, fldPath *field.Path) error {
+	if cloudProfileConfig == nil ||
+		pointer.SafeDeref(newConfig.NetworkAccessType) != apismetal.NetworkAccessForbidden ||
+		pointer.SafeDeref(oldConfig.NetworkAccessType) == apismetal.NetworkAccessForbidden {
+		return nil
+	}
+
+	mcp, partition, err := helper.FindMetalControlPlane(cloudProfileConfig, infraConfig.PartitionID)
+	if err != nil {
+		return err
+	}
+
+	if partition.NetworkIsolation == nil {
+		// reported by the validation of the network access type
+		return nil
+	}
+
+	credentials, err := s.readShootCredentials(ctx, shoot)
+	if errors.Is(err, metalclient.ErrWorkloadIdentity) {
+		// the migration of shoots with workload identities is only held back by the worker controller
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read metal-api credentials of shoot: %w", err)
+	}
+
+	mclient, err := metalclient.NewClientFromCredentials(mcp.Endpoint, credentials)
+	if err != nil {
+		return err
+	}
+
+	resp, err := mclient.IP().FindIPs(metalip.NewFindIPsParams().WithBody(&models.V1IPFindRequest{
+		Projectid: infraConfig.ProjectID,
+	}).WithContext(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("unable to list ips from metal-api: %w", err)
+	}
+
+	var loadBalancerIPs []string
+	for _, ip := range resp.Payload {
+		if slices.ContainsFunc(ip.Tags, func(t string) bool {
+			key, value, _ := strings.Cut(t, "=")
+			return key == tag.ClusterServiceFQN && strings.HasPrefix(value, string(shoot.UID)+"/")
+		}) {
+			loadBalancerIPs = append(loadBalancerIPs, *ip.Ipaddress)
+		}
+	}
+
+	ingress := slices.Clone(partition.NetworkIsolation.AllowedNetworks.Ingress)
+	if newConfig.AdditionalAllowedNetworks != nil {
+		ingress = append(ingress, newConfig.AdditionalAllowedNetworks.Ingress...)
+	}
+
+	if outside := helper.IPsOutsideOf(loadBalancerIPs, ingress); len(outside) > 0 {
+		return field.Forbidden(fldPath.Child("networkAccessType"), fmt.Sprintf("load balancer ips of the cluster are not contained in the allowed ingress networks: %s", strings.Join(outside, ", ")))
+	}
+
+	return nil
+}
+
+// readShootCredentials returns the metal-api credentials referenced by the credentials or secret binding of the shoot.
+// metalclient.ErrWorkloadIdentity is returned if the shoot references a workload identity.
+func (s *shoot) readShootCredentials(ctx context.Context, shoot *core.Shoot) (*metal.Credentials, error) {
+	// Explicitly use the client.Reader to prevent controller-runtime to start Informers for the bindings and secrets
+	// under the hood.
+	return metalclient.ReadCredentialsFromBinding(ctx, s.apiReader, shoot.Namespace, shoot.Spec.CredentialsBindingName, shoot.Spec.SecretBindingName) //nolint:staticcheck
+}
@@ -6,6 +6,8 @@ import (
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,12 +17,14 @@ import (
 )
 
 type secretBinding struct {
+	client    client.Client
 	apiReader client.Reader
 }
 
 // NewSecretBindingValidator returns a new instance of a secret binding validator.
 func NewSecretBindingValidator(mgr manager.Manager) extensionswebhook.Validator {
 	return &secretBinding{
+		client:    mgr.GetClient(),
 		apiReader: mgr.GetAPIReader(),
 	}
 }
@@ -56,5 +60,16 @@ func (sb *secretBinding) Validate(ctx context.Context, newObj, oldObj client.Obj
 		return err
 	}
 
-	return metalvalidation.ValidateCloudProviderSecret(secret)
+	if err := metalvalidation.ValidateCloudProviderSecret(secret); err != nil {
+		return err
+	}
+
+	shoots, err := shootsUsingBinding(ctx, sb.apiReader, secretBinding.Namespace, func(shoot *gardencorev1beta1.Shoot) bool {
+		return pointer.SafeDeref(shoot.Spec.SecretBindingName) == secretBinding.Name //nolint:staticcheck
+	})
+	if err != nil {
+		return err
+	}
+
+	return validateCredentialsAgainstMetalAPI(ctx, sb.client, shoots, secret)
 }
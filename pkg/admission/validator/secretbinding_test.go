@@ -0,0 +1,197 @@
+package validator_test
+
+import (
+	"context"
+	"fmt"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	mockclient "github.com/gardener/gardener/third_party/mock/controller-runtime/client"
+	mockmanager "github.com/gardener/gardener/third_party/mock/controller-runtime/manager"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/admission/validator"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("SecretBinding validator", func() {
+	Describe("#Validate", func() {
+		const (
+			namespace   = "garden-dev"
+			name        = "my-provider-account"
+			bindingName = "my-binding"
+		)
+
+		var (
+			secretBindingValidator extensionswebhook.Validator
+
+			ctrl      *gomock.Controller
+			mgr       *mockmanager.MockManager
+			apiReader *mockclient.MockReader
+			c         client.Client
+
+			ctx           = context.TODO()
+			secretBinding *core.SecretBinding
+
+			fakeErr = fmt.Errorf("fake err")
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+
+			mgr = mockmanager.NewMockManager(ctrl)
+
+			apiReader = mockclient.NewMockReader(ctrl)
+			mgr.EXPECT().GetAPIReader().Return(apiReader)
+
+			scheme := runtime.NewScheme()
+			Expect(gardencorev1beta1.AddToScheme(scheme)).To(Succeed())
+			c = fakeclient.NewClientBuilder().WithScheme(scheme).Build()
+			mgr.EXPECT().GetClient().Return(c)
+
+			secretBindingValidator = validator.NewSecretBindingValidator(
+				mgr,
+			)
+
+			secretBinding = &core.SecretBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: bindingName, Namespace: namespace},
+				SecretRef: corev1.SecretReference{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Provider: &core.SecretBindingProvider{
+					Type: metal.Type,
+				},
+			}
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should return err when obj is not a SecretBinding", func() {
+			err := secretBindingValidator.Validate(ctx, &corev1.Secret{}, nil)
+			Expect(err).To(MatchError("wrong object type *v1.Secret"))
+		})
+
+		It("should return err when oldObj is not a SecretBinding", func() {
+			err := secretBindingValidator.Validate(ctx, secretBinding, &corev1.Secret{})
+			Expect(err).To(MatchError("wrong object type *v1.Secret for old object"))
+		})
+
+		It("should return nil when the provider type did not change", func() {
+			Expect(secretBindingValidator.Validate(ctx, secretBinding, secretBinding.DeepCopy())).To(Succeed())
+		})
+
+		It("should return err if it fails to get the corresponding Secret", func() {
+			apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&corev1.Secret{})).Return(fakeErr)
+
+			err := secretBindingValidator.Validate(ctx, secretBinding, nil)
+			Expect(err).To(MatchError(fakeErr))
+		})
+
+		It("should return err when the corresponding Secret does not contain credentials", func() {
+			apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&corev1.Secret{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret, _ ...client.GetOption) error {
+					secret := &corev1.Secret{Data: map[string][]byte{
+						"foo": []byte("bar"),
+					}}
+					*obj = *secret
+					return nil
+				})
+
+			err := secretBindingValidator.Validate(ctx, secretBinding, nil)
+			Expect(err).To(MatchError("either hmac or api key must be set"))
+		})
+
+		Context("online validation", func() {
+			var shoots []gardencorev1beta1.Shoot
+
+			BeforeEach(func() {
+				apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&corev1.Secret{})).
+					DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret, _ ...client.GetOption) error {
+						secret := &corev1.Secret{Data: map[string][]byte{
+							metal.APIHMac: []byte(`a-secure-secret`),
+						}}
+						*obj = *secret
+						return nil
+					})
+
+				shoots = nil
+			})
+
+			expectShoots := func(err error) {
+				apiReader.EXPECT().List(ctx, gomock.AssignableToTypeOf(&gardencorev1beta1.ShootList{}), client.InNamespace(namespace)).
+					DoAndReturn(func(_ context.Context, list *gardencorev1beta1.ShootList, _ ...client.ListOption) error {
+						list.Items = shoots
+						return err
+					})
+			}
+
+			It("should succeed when the binding is not used by any shoot", func() {
+				expectShoots(nil)
+
+				Expect(secretBindingValidator.Validate(ctx, secretBinding, nil)).To(Succeed())
+			})
+
+			It("should not look up the credentials when the validation is disabled in the cloud profile of the shoot", func() {
+				Expect(c.Create(ctx, &gardencorev1beta1.CloudProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "metal"},
+					Spec: gardencorev1beta1.CloudProfileSpec{
+						Type: metal.Type,
+						ProviderConfig: &runtime.RawExtension{
+							Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","metalControlPlanes":{"prod":{"endpoint":"https://metal-api.invalid","partitions":{"partition-a":{}}}}}`),
+						},
+					},
+				})).To(Succeed())
+				shoots = []gardencorev1beta1.Shoot{
+					newShootUsingBinding("shoot-a", "metal", pointer.Pointer(bindingName), nil),
+					newShootUsingBinding("shoot-b", "unknown", pointer.Pointer("other-binding"), nil),
+				}
+				expectShoots(nil)
+
+				Expect(secretBindingValidator.Validate(ctx, secretBinding, nil)).To(Succeed())
+			})
+
+			It("should return err if the cloud profile of a shoot which uses the binding does not exist", func() {
+				shoots = []gardencorev1beta1.Shoot{newShootUsingBinding("shoot-a", "unknown", pointer.Pointer(bindingName), nil)}
+				expectShoots(nil)
+
+				err := secretBindingValidator.Validate(ctx, secretBinding, nil)
+				Expect(err).To(MatchError(ContainSubstring(`unable to determine the cloud profile of shoot "shoot-a" which uses the binding`)))
+			})
+
+			It("should return err if it fails to list the shoots", func() {
+				expectShoots(fakeErr)
+
+				err := secretBindingValidator.Validate(ctx, secretBinding, nil)
+				Expect(err).To(MatchError(fakeErr))
+			})
+		})
+	})
+})
+
+func newShootUsingBinding(name, cloudProfileName string, secretBindingName, credentialsBindingName *string) gardencorev1beta1.Shoot {
+	return gardencorev1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "garden-dev"},
+		Spec: gardencorev1beta1.ShootSpec{
+			CloudProfile:           &gardencorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: cloudProfileName},
+			SecretBindingName:      secretBindingName,
+			CredentialsBindingName: credentialsBindingName,
+			Provider: gardencorev1beta1.Provider{
+				Type: metal.Type,
+				InfrastructureConfig: &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","partitionID":"partition-a","projectID":"project-a"}`),
+				},
+			},
+		},
+	}
+}
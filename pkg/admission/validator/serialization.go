@@ -18,6 +18,15 @@ func decodeControlPlaneConfig(decoder runtime.Decoder, cp *runtime.RawExtension,
 	return controlPlaneConfig, nil
 }
 
+func decodeWorkerConfig(decoder runtime.Decoder, worker *runtime.RawExtension, fldPath *field.Path) (*metal.WorkerConfig, error) {
+	workerConfig := &metal.WorkerConfig{}
+	if err := util.Decode(decoder, worker.Raw, workerConfig); err != nil {
+		return nil, field.Invalid(fldPath, string(worker.Raw), fmt.Sprintf("isn't a supported version: %s", err))
+	}
+
+	return workerConfig, nil
+}
+
 func decodeInfrastructureConfig(decoder runtime.Decoder, infra *runtime.RawExtension, fldPath *field.Path) (*metal.InfrastructureConfig, error) {
 	infraConfig := &metal.InfrastructureConfig{}
 	if err := util.Decode(decoder, infra.Raw, infraConfig); err != nil {
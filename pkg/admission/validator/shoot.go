@@ -25,14 +25,16 @@ import (
 // NewShootValidator returns a new instance of a shoot validator.
 func NewShootValidator(mgr manager.Manager) extensionswebhook.Validator {
 	return &shoot{
-		client:  mgr.GetClient(),
-		decoder: serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		client:    mgr.GetClient(),
+		apiReader: mgr.GetAPIReader(),
+		decoder:   serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
 	}
 }
 
 type shoot struct {
-	client  client.Client
-	decoder runtime.Decoder
+	client    client.Client
+	apiReader client.Reader
+	decoder   runtime.Decoder
 }
 
 // Validate validates the given shoot object.
@@ -53,7 +55,8 @@ func (s *shoot) Validate(ctx context.Context, new, old client.Object) error {
 	return s.validateShootCreation(ctx, shoot)
 }
 
-func (s *shoot) validateShoot(ctx context.Context, shoot *core.Shoot) error {
+// validateShoot validates the given shoot. The old infrastructure config is nil on creation.
+func (s *shoot) validateShoot(ctx context.Context, oldInfraConfig *apismetal.InfrastructureConfig, shoot *core.Shoot) error {
 	// Provider validation
 	fldPath := field.NewPath("spec", "provider")
 
@@ -62,6 +65,10 @@ func (s *shoot) validateShoot(ctx context.Context, shoot *core.Shoot) error {
 		return field.Required(field.NewPath("metadata", "annotations"), fmt.Sprintf("cluster must be annotated with a tenant using the annotations: %s", tag.ClusterTenant))
 	}
 
+	if isWorkerless(shoot) {
+		return validateWorkerlessShoot(shoot, fldPath)
+	}
+
 	// InfrastructureConfig
 	infraConfigFldPath := fldPath.Child("infrastructureConfig")
 
@@ -98,6 +105,17 @@ func (s *shoot) validateShoot(ctx context.Context, shoot *core.Shoot) error {
 		return errList.ToAggregate()
 	}
 
+	if err := s.validateFirewallNetworks(ctx, shoot, infraConfig, cloudProfileConfig, infraConfigFldPath); err != nil {
+		return err
+	}
+
+	// the project is only looked up in the metal-api when it is set, not on every update of the shoot
+	if oldInfraConfig == nil || oldInfraConfig.ProjectID != infraConfig.ProjectID {
+		if err := s.validateProject(ctx, shoot, infraConfig, cloudProfileConfig, infraConfigFldPath); err != nil {
+			return err
+		}
+	}
+
 	controlPlaneConfigFldPath := fldPath.Child("controlPlaneConfig")
 
 	controlPlaneConfig, err := decodeControlPlaneConfig(s.decoder, shoot.Spec.Provider.ControlPlaneConfig, fldPath.Child("controlPlaneConfig"))
@@ -118,12 +136,40 @@ func (s *shoot) validateShoot(ctx context.Context, shoot *core.Shoot) error {
 		return errList.ToAggregate()
 	}
 
-	return nil
+	workerConfigs := make([]*apismetal.WorkerConfig, len(shoot.Spec.Provider.Workers))
+	for i, worker := range shoot.Spec.Provider.Workers {
+		if worker.ProviderConfig == nil {
+			continue
+		}
+
+		workerConfigFldPath := fldPath.Child("workers").Index(i).Child("providerConfig")
+
+		workerConfig, err := decodeWorkerConfig(s.decoder, worker.ProviderConfig, workerConfigFldPath)
+		if err != nil {
+			return err
+		}
+
+		if errList := metalvalidation.ValidateWorkerConfig(workerConfig, workerConfigFldPath); len(errList) != 0 {
+			return errList.ToAggregate()
+		}
+
+		workerConfigs[i] = workerConfig
+	}
+
+	return s.validateWorkerNetworks(ctx, shoot, workerConfigs, controlPlaneConfig, infraConfig, cloudProfileConfig, fldPath)
 }
 
 func (s *shoot) validateShootUpdate(ctx context.Context, oldShoot, shoot *core.Shoot) error {
 	fldPath := field.NewPath("spec", "provider")
 
+	if shoot.Annotations[tag.ClusterTenant] != oldShoot.Annotations[tag.ClusterTenant] {
+		return field.Forbidden(field.NewPath("metadata", "annotations"), "tenant annotation of a shoot is immutable")
+	}
+
+	if isWorkerless(shoot) {
+		return s.validateShoot(ctx, nil, shoot)
+	}
+
 	// InfrastructureConfig update
 	if shoot.Spec.Provider.InfrastructureConfig == nil {
 		return field.Required(fldPath.Child("infrastructureConfig"), "InfrastructureConfig must be set for metal shoots")
@@ -165,14 +211,39 @@ func (s *shoot) validateShootUpdate(ctx context.Context, oldShoot, shoot *core.S
 		}
 	}
 
-	if shoot.Annotations[tag.ClusterTenant] != oldShoot.Annotations[tag.ClusterTenant] {
-		return field.Forbidden(field.NewPath("metadata", "annotations"), "tenant annotation of a shoot is immutable")
+	if shoot.Spec.Provider.ControlPlaneConfig != nil {
+		controlPlaneConfigFldPath := fldPath.Child("controlPlaneConfig")
+
+		controlPlaneConfig, err := decodeControlPlaneConfig(s.decoder, shoot.Spec.Provider.ControlPlaneConfig, controlPlaneConfigFldPath)
+		if err != nil {
+			return err
+		}
+
+		oldControlPlaneConfig := &apismetal.ControlPlaneConfig{}
+		if oldShoot.Spec.Provider.ControlPlaneConfig != nil {
+			oldControlPlaneConfig, err = decodeControlPlaneConfig(s.decoder, oldShoot.Spec.Provider.ControlPlaneConfig, controlPlaneConfigFldPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		if errList := metalvalidation.ValidateControlPlaneConfigUpdate(oldControlPlaneConfig, controlPlaneConfig, controlPlaneConfigFldPath); len(errList) != 0 {
+			return errList.ToAggregate()
+		}
+
+		if err := s.validateNetworkAccessMigration(ctx, shoot, oldControlPlaneConfig, controlPlaneConfig, infraConfig, cloudProfileConfig, controlPlaneConfigFldPath); err != nil {
+			return err
+		}
 	}
 
-	return s.validateShoot(ctx, shoot)
+	return s.validateShoot(ctx, oldInfraConfig, shoot)
 }
 
 func (s *shoot) validateShootCreation(ctx context.Context, shoot *core.Shoot) error {
+	if isWorkerless(shoot) {
+		return s.validateShoot(ctx, nil, shoot)
+	}
+
 	fldPath := field.NewPath("spec", "provider")
 	infraConfig, err := decodeInfrastructureConfig(s.decoder, shoot.Spec.Provider.InfrastructureConfig, fldPath.Child("infrastructureConfig"))
 	if err != nil {
@@ -183,7 +254,28 @@ func (s *shoot) validateShootCreation(ctx context.Context, shoot *core.Shoot) er
 		return err
 	}
 
-	return s.validateShoot(ctx, shoot)
+	return s.validateShoot(ctx, nil, shoot)
+}
+
+// isWorkerless returns true if the shoot has no workers. Such a shoot only consists of a control plane, it has
+// neither a private network nor a firewall.
+func isWorkerless(shoot *core.Shoot) bool {
+	return len(shoot.Spec.Provider.Workers) == 0
+}
+
+// validateWorkerlessShoot validates a shoot without workers, which must not carry any provider configuration
+// as there is no infrastructure for it.
+func validateWorkerlessShoot(shoot *core.Shoot, fldPath *field.Path) error {
+	allErrs := field.ErrorList{}
+
+	if shoot.Spec.Provider.InfrastructureConfig != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("infrastructureConfig"), "must not be set for workerless shoots"))
+	}
+	if shoot.Spec.Provider.ControlPlaneConfig != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneConfig"), "must not be set for workerless shoots"))
+	}
+
+	return allErrs.ToAggregate()
 }
 
 // func ValidateInfrastructureConfigAgainstCloudProfile(infra *apismetal.InfrastructureConfig, shoot *core.Shoot, cloudProfile *gardencorev1beta1.CloudProfile, cloudProfileConfig *apismetal.CloudProfileConfig, fldPath *field.Path) field.ErrorList {
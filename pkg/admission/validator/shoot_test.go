@@ -0,0 +1,194 @@
+package validator_test
+
+import (
+	"context"
+	"fmt"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	mockclient "github.com/gardener/gardener/third_party/mock/controller-runtime/client"
+	mockmanager "github.com/gardener/gardener/third_party/mock/controller-runtime/manager"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/admission/validator"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/install"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Shoot validator", func() {
+	Describe("#Validate", func() {
+		var (
+			shootValidator extensionswebhook.Validator
+
+			ctrl *gomock.Controller
+			mgr  *mockmanager.MockManager
+
+			ctx   = context.TODO()
+			shoot *core.Shoot
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+
+			mgr = mockmanager.NewMockManager(ctrl)
+			mgr.EXPECT().GetClient().Return(mockclient.NewMockClient(ctrl))
+			mgr.EXPECT().GetAPIReader().Return(mockclient.NewMockReader(ctrl))
+			mgr.EXPECT().GetScheme().Return(runtime.NewScheme())
+
+			shootValidator = validator.NewShootValidator(mgr)
+
+			shoot = &core.Shoot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shoot",
+					Namespace: "garden-dev",
+					Annotations: map[string]string{
+						tag.ClusterTenant: "tenant-a",
+					},
+				},
+				Spec: core.ShootSpec{
+					Provider: core.Provider{
+						Type: "metal",
+					},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		Context("workerless shoot", func() {
+			It("should succeed without provider configuration", func() {
+				Expect(shootValidator.Validate(ctx, shoot, nil)).To(Succeed())
+			})
+
+			It("should succeed on update without provider configuration", func() {
+				Expect(shootValidator.Validate(ctx, shoot, shoot.DeepCopy())).To(Succeed())
+			})
+
+			It("should require the tenant annotation", func() {
+				shoot.Annotations = nil
+
+				err := shootValidator.Validate(ctx, shoot, nil)
+				Expect(err).To(MatchError(ContainSubstring("cluster must be annotated with a tenant")))
+			})
+
+			It("should forbid to change the tenant annotation", func() {
+				oldShoot := shoot.DeepCopy()
+				oldShoot.Annotations[tag.ClusterTenant] = "tenant-b"
+
+				err := shootValidator.Validate(ctx, shoot, oldShoot)
+				Expect(err).To(MatchError(ContainSubstring("tenant annotation of a shoot is immutable")))
+			})
+
+			It("should forbid an infrastructure and a control plane config", func() {
+				shoot.Spec.Provider.InfrastructureConfig = &runtime.RawExtension{Raw: []byte("{}")}
+				shoot.Spec.Provider.ControlPlaneConfig = &runtime.RawExtension{Raw: []byte("{}")}
+
+				err := shootValidator.Validate(ctx, shoot, nil)
+				Expect(err).To(MatchError(And(
+					ContainSubstring("spec.provider.infrastructureConfig: Forbidden: must not be set for workerless shoots"),
+					ContainSubstring("spec.provider.controlPlaneConfig: Forbidden: must not be set for workerless shoots"),
+				)))
+			})
+		})
+
+		Context("shoot with workers", func() {
+			var (
+				apiReader *mockclient.MockReader
+
+				fakeErr = fmt.Errorf("fake err")
+			)
+
+			BeforeEach(func() {
+				scheme := runtime.NewScheme()
+				install.Install(scheme)
+				Expect(gardencorev1beta1.AddToScheme(scheme)).To(Succeed())
+
+				cloudProfile := &gardencorev1beta1.CloudProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "metal"},
+					Spec: gardencorev1beta1.CloudProfileSpec{
+						Type: "metal",
+						MachineImages: []gardencorev1beta1.MachineImage{
+							{
+								Name:     "ubuntu",
+								Versions: []gardencorev1beta1.MachineImageVersion{{ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "24.4"}}},
+							},
+						},
+						ProviderConfig: &runtime.RawExtension{
+							Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","metalControlPlanes":{"prod":{"endpoint":"https://metal-api.invalid","validateCredentials":true,"firewallControllerVersions":[{"version":"v2.0.0","url":"https://firewall-controller"}],"partitions":{"partition-a":{"firewallTypes":["c1-xlarge-x86"],"networkIsolation":{"allowedNetworks":{"egress":["10.0.0.0/8"]}}}}}}}`),
+						},
+					},
+				}
+
+				apiReader = mockclient.NewMockReader(ctrl)
+
+				mgr = mockmanager.NewMockManager(ctrl)
+				mgr.EXPECT().GetClient().Return(fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(cloudProfile).Build())
+				mgr.EXPECT().GetAPIReader().Return(apiReader)
+				mgr.EXPECT().GetScheme().Return(scheme)
+
+				shootValidator = validator.NewShootValidator(mgr)
+
+				shoot.Spec.CloudProfile = &core.CloudProfileReference{Kind: "CloudProfile", Name: "metal"}
+				shoot.Spec.CredentialsBindingName = new("credentials")
+				shoot.Spec.Provider.InfrastructureConfig = &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","projectID":"project-a","partitionID":"partition-a","firewall":{"size":"c1-xlarge-x86","image":"firewall-ubuntu-3.0","networks":["internet"],"controllerVersion":"v2.0.0"}}`),
+				}
+				shoot.Spec.Provider.ControlPlaneConfig = &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig"}`),
+				}
+				shoot.Spec.Provider.Workers = []core.Worker{
+					{
+						Name: "default",
+						Machine: core.Machine{
+							Type:  "c1-xlarge-x86",
+							Image: &core.ShootMachineImage{Name: "ubuntu", Version: new("24.4")},
+						},
+					},
+				}
+			})
+
+			Context("project validation", func() {
+				It("should look up the project on creation", func() {
+					apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: "garden-dev", Name: "credentials"}, gomock.AssignableToTypeOf(&securityv1alpha1.CredentialsBinding{})).Return(fakeErr)
+
+					err := shootValidator.Validate(ctx, shoot, nil)
+					Expect(err).To(MatchError(ContainSubstring("unable to read metal-api credentials of shoot: fake err")))
+				})
+
+				It("should not look up the project on update when the project did not change", func() {
+					Expect(shootValidator.Validate(ctx, shoot, shoot.DeepCopy())).To(Succeed())
+				})
+			})
+
+			Context("worker networks", func() {
+				BeforeEach(func() {
+					shoot.Spec.Provider.Workers[0].ProviderConfig = &runtime.RawExtension{
+						Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","additionalNetworks":["storage"]}`),
+					}
+				})
+
+				It("should allow additional networks of baseline shoots without looking them up", func() {
+					Expect(shootValidator.Validate(ctx, shoot, shoot.DeepCopy())).To(Succeed())
+				})
+
+				It("should forbid additional networks of isolated shoots if they are not looked up", func() {
+					shoot.Spec.Provider.ControlPlaneConfig = &runtime.RawExtension{
+						Raw: []byte(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","networkAccessType":"restricted"}`),
+					}
+
+					err := shootValidator.Validate(ctx, shoot, shoot.DeepCopy())
+					Expect(err).To(MatchError(ContainSubstring("spec.provider.workers[0].providerConfig.additionalNetworks: Forbidden")))
+				})
+			})
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/install"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -15,6 +16,9 @@ import (
 var (
 	Codec  runtime.Codec
 	Scheme *runtime.Scheme
+
+	// StrictCodec rejects unknown and duplicate fields, it is used for the admission configuration.
+	StrictCodec runtime.Codec
 )
 
 func init() {
@@ -28,6 +32,14 @@ func init() {
 		schema.GroupVersion{Version: "v1alpha1"},
 		runtime.InternalGroupVersioner,
 	)
+	strictYAMLSerializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, Scheme, Scheme, json.SerializerOptions{Yaml: true, Strict: true})
+	StrictCodec = versioning.NewDefaultingCodecForScheme(
+		Scheme,
+		strictYAMLSerializer,
+		strictYAMLSerializer,
+		schema.GroupVersion{Version: "v1alpha1"},
+		runtime.InternalGroupVersioner,
+	)
 }
 
 // LoadFromFile takes a filename and de-serializes the contents into ControllerConfiguration object.
@@ -56,3 +68,37 @@ func Load(data []byte) (*config.ControllerConfiguration, error) {
 
 	return decoded.(*config.ControllerConfiguration), nil
 }
+
+// LoadAdmissionConfigurationFromFile takes a filename and de-serializes the contents into AdmissionConfiguration object.
+func LoadAdmissionConfigurationFromFile(filename string) (*config.AdmissionConfiguration, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadAdmissionConfiguration(bytes)
+}
+
+// LoadAdmissionConfiguration takes a byte slice and de-serializes the contents into AdmissionConfiguration object.
+// Unknown fields are rejected. An empty byte slice results in the default configuration.
+func LoadAdmissionConfiguration(data []byte) (*config.AdmissionConfiguration, error) {
+	cfg := &config.AdmissionConfiguration{}
+
+	if len(data) == 0 {
+		defaults := &v1alpha1.AdmissionConfiguration{}
+		Scheme.Default(defaults)
+
+		if err := Scheme.Convert(defaults, cfg, nil); err != nil {
+			return nil, err
+		}
+
+		return cfg, nil
+	}
+
+	decoded, _, err := StrictCodec.Decode(data, &schema.GroupVersionKind{Version: "v1alpha1", Kind: "AdmissionConfiguration"}, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return decoded.(*config.AdmissionConfiguration), nil
+}
@@ -0,0 +1,77 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+)
+
+func TestLoadAdmissionConfiguration(t *testing.T) {
+	defaults, err := LoadAdmissionConfiguration(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    func() *config.AdmissionConfiguration
+		wantErr bool
+	}{
+		{
+			name: "defaults are applied",
+			data: `apiVersion: metal.provider.extensions.config.gardener.cloud/v1alpha1
+kind: AdmissionConfiguration
+shootDefaults:
+  maxPods: 110
+shootDefaultsOverrides:
+- partition: partition-a
+  shootDefaults:
+    cilium:
+      mtu: 9000
+`,
+			want: func() *config.AdmissionConfiguration {
+				want := defaults.DeepCopy()
+				want.ShootDefaults.MaxPods = new(int32(110))
+				want.ShootDefaultsOverrides = []config.ShootDefaultsOverride{
+					{
+						Partition: "partition-a",
+						ShootDefaults: config.ShootDefaults{
+							Cilium: &config.CiliumDefaults{MTU: new(int32(9000))},
+						},
+					},
+				}
+				return want
+			},
+		},
+		{
+			name: "unknown fields are rejected",
+			data: `apiVersion: metal.provider.extensions.config.gardener.cloud/v1alpha1
+kind: AdmissionConfiguration
+shootDefaults:
+  maxPod: 110
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadAdmissionConfiguration([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadAdmissionConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want := tt.want()
+			// the type meta is dropped on conversion to the internal version
+			got.TypeMeta = want.TypeMeta
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("LoadAdmissionConfiguration() diff = %s", diff)
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ var (
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ControllerConfiguration{},
+		&AdmissionConfiguration{},
 	)
 	return nil
 }
@@ -45,6 +45,12 @@ type ControllerConfiguration struct {
 
 	// NetworkPolicies contains extra configuration for network policies
 	NetworkPolicies *NetworkPolicies
+
+	// FirewallMonitoring contains the configuration for collecting firewall metrics in the seed
+	FirewallMonitoring *FirewallMonitoring
+
+	// MachineClasses contains the configuration for the machine classes generated by the worker controller
+	MachineClasses *MachineClasses
 }
 
 // MachineImage is a mapping from logical names and versions to GCP-specific identifiers.
@@ -139,6 +145,23 @@ type ImagePullSecret struct {
 type NetworkPolicies struct {
 	// IngressController contains extra configuration for network policies regarding an ingress-controller
 	IngressController *NetpolsIngressController
+	// DefaultClusterwideNetworkPolicies is the list of default cluster-wide network policies which are deployed into
+	// the shoots of this seed. If not set, all default policies are deployed.
+	DefaultClusterwideNetworkPolicies []string
+}
+
+// FirewallMonitoring contains the configuration for collecting firewall metrics in the seed
+type FirewallMonitoring struct {
+	// Enabled deploys a scrape config for the exporters of the firewalls into the shoot namespaces, such that the
+	// firewall metrics are collected by the shoot prometheus of the seed
+	Enabled bool
+}
+
+// MachineClasses contains the configuration for the machine classes generated by the worker controller
+type MachineClasses struct {
+	// CredentialsFromSecretRefOnly omits the metal-api credentials from the machine class secrets, such that the
+	// machine-controller-manager reads them from the credentials secret ref of the machine class only
+	CredentialsFromSecretRefOnly bool
 }
 
 // NetpolsIngressController contains extra configuration for network policies regarding an ingress-controller
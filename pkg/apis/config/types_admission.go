@@ -0,0 +1,81 @@
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AdmissionConfiguration defines the configuration for the metal admission webhooks.
+type AdmissionConfiguration struct {
+	metav1.TypeMeta
+
+	// ShootDefaults are the values the shoot mutator defaults shoots with.
+	ShootDefaults ShootDefaults
+
+	// ShootDefaultsOverrides override the shoot defaults for shoots of a cloud profile or a partition. Overrides
+	// are applied in the given order, such that later overrides take precedence over earlier ones.
+	ShootDefaultsOverrides []ShootDefaultsOverride
+}
+
+// ShootDefaults contains the values the shoot mutator defaults shoots with.
+type ShootDefaults struct {
+	// MaxPods is the default maximum amount of pods per node.
+	MaxPods *int32
+	// NodeCIDRMaskSize is the default mask size of the pod cidr of a node.
+	NodeCIDRMaskSize *int32
+	// PodsCIDR is the default cidr of the pod network.
+	PodsCIDR *string
+	// ServicesCIDR is the default cidr of the service network.
+	ServicesCIDR *string
+	// NetworkType is the default networking extension, either calico or cilium.
+	NetworkType *string
+	// Calico contains the defaults of the calico networking extension.
+	Calico *CalicoDefaults
+	// Cilium contains the defaults of the cilium networking extension.
+	Cilium *CiliumDefaults
+}
+
+// CalicoDefaults contains the defaults of the calico networking extension.
+type CalicoDefaults struct {
+	// Backend is the default calico backend.
+	Backend *string
+	// KubeProxyEnabled defaults whether kube-proxy is deployed alongside calico.
+	KubeProxyEnabled *bool
+	// PoolMode is the default mode of the calico ipv4 pool.
+	PoolMode *string
+	// TyphaEnabled defaults whether typha is deployed.
+	TyphaEnabled *bool
+}
+
+// CiliumDefaults contains the defaults of the cilium networking extension.
+type CiliumDefaults struct {
+	// HubbleEnabled defaults whether hubble is deployed.
+	HubbleEnabled *bool
+	// KubeProxyEnabled defaults whether kube-proxy is deployed alongside cilium.
+	KubeProxyEnabled *bool
+	// TunnelMode is the default tunnel mode.
+	TunnelMode *string
+	// Devices are the default devices cilium attaches to.
+	Devices []string
+	// DirectRoutingDevice is the default device used for direct routing.
+	DirectRoutingDevice *string
+	// BGPControlPlaneEnabled defaults whether the bgp control plane is enabled.
+	BGPControlPlaneEnabled *bool
+	// IPv4NativeRoutingCIDREnabled defaults whether the ipv4 native routing cidr is enabled.
+	IPv4NativeRoutingCIDREnabled *bool
+	// LoadBalancingMode is the default load balancing mode.
+	LoadBalancingMode *string
+	// MTU is the default mtu of the pod network.
+	MTU *int32
+}
+
+// ShootDefaultsOverride overrides the shoot defaults for shoots of a cloud profile or a partition.
+type ShootDefaultsOverride struct {
+	// CloudProfile is the name of the cloud profile the override applies to. Applies to all cloud profiles if empty.
+	CloudProfile string
+	// Partition is the partition the override applies to. Applies to all partitions if empty.
+	Partition string
+	// ShootDefaults are the values which override the shoot defaults, unset values are not overridden.
+	ShootDefaults ShootDefaults
+}
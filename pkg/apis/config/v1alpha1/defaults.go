@@ -1,9 +1,80 @@
 package v1alpha1
 
 import (
+	calicoextensionv1alpha1 "github.com/gardener/gardener-extension-networking-calico/pkg/apis/calico/v1alpha1"
+	ciliumextensionv1alpha1 "github.com/gardener/gardener-extension-networking-cilium/pkg/apis/cilium/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_AdmissionConfiguration sets the defaults of the shoot defaults. Overrides are not defaulted as unset
+// values do not override the shoot defaults.
+func SetDefaults_AdmissionConfiguration(obj *AdmissionConfiguration) {
+	d := &obj.ShootDefaults
+
+	if d.MaxPods == nil {
+		d.MaxPods = new(int32(250))
+	}
+	if d.NodeCIDRMaskSize == nil {
+		d.NodeCIDRMaskSize = new(int32(23))
+	}
+	if d.PodsCIDR == nil {
+		d.PodsCIDR = new("10.240.0.0/13")
+	}
+	if d.ServicesCIDR == nil {
+		d.ServicesCIDR = new("10.248.0.0/18")
+	}
+	if d.NetworkType == nil {
+		d.NetworkType = new("calico")
+	}
+
+	if d.Calico == nil {
+		d.Calico = &CalicoDefaults{}
+	}
+	if d.Calico.Backend == nil {
+		d.Calico.Backend = new(string(calicoextensionv1alpha1.None))
+	}
+	if d.Calico.KubeProxyEnabled == nil {
+		d.Calico.KubeProxyEnabled = new(true)
+	}
+	if d.Calico.PoolMode == nil {
+		d.Calico.PoolMode = new(string(calicoextensionv1alpha1.Never))
+	}
+	if d.Calico.TyphaEnabled == nil {
+		d.Calico.TyphaEnabled = new(false)
+	}
+
+	if d.Cilium == nil {
+		d.Cilium = &CiliumDefaults{}
+	}
+	if d.Cilium.HubbleEnabled == nil {
+		d.Cilium.HubbleEnabled = new(true)
+	}
+	if d.Cilium.KubeProxyEnabled == nil {
+		d.Cilium.KubeProxyEnabled = new(false)
+	}
+	if d.Cilium.TunnelMode == nil {
+		d.Cilium.TunnelMode = new(string(ciliumextensionv1alpha1.Disabled))
+	}
+	if d.Cilium.Devices == nil {
+		d.Cilium.Devices = []string{"lan+", "lo"}
+	}
+	if d.Cilium.DirectRoutingDevice == nil {
+		d.Cilium.DirectRoutingDevice = new("lo")
+	}
+	if d.Cilium.BGPControlPlaneEnabled == nil {
+		d.Cilium.BGPControlPlaneEnabled = new(true)
+	}
+	if d.Cilium.IPv4NativeRoutingCIDREnabled == nil {
+		d.Cilium.IPv4NativeRoutingCIDREnabled = new(true)
+	}
+	if d.Cilium.LoadBalancingMode == nil {
+		d.Cilium.LoadBalancingMode = new(string(ciliumextensionv1alpha1.DSR))
+	}
+	if d.Cilium.MTU == nil {
+		d.Cilium.MTU = new(int32(1440))
+	}
+}
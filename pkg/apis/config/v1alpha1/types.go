@@ -49,6 +49,14 @@ type ControllerConfiguration struct {
 	// NetworkPolicies contains extra configuration for network policies
 	// +optional
 	NetworkPolicies *NetworkPolicies `json:"networkPolicies,omitempty"`
+
+	// FirewallMonitoring contains the configuration for collecting firewall metrics in the seed
+	// +optional
+	FirewallMonitoring *FirewallMonitoring `json:"firewallMonitoring,omitempty"`
+
+	// MachineClasses contains the configuration for the machine classes generated by the worker controller
+	// +optional
+	MachineClasses *MachineClasses `json:"machineClasses,omitempty"`
 }
 
 // MachineImage is a mapping from logical names and versions to GCP-specific identifiers.
@@ -148,6 +156,25 @@ type NetworkPolicies struct {
 	// IngressController contains extra configuration for network policies regarding an ingress-controller
 	// +optional
 	IngressController *NetpolsIngressController `json:"ingressController,omitempty"`
+	// DefaultClusterwideNetworkPolicies is the list of default cluster-wide network policies which are deployed into
+	// the shoots of this seed. If not set, all default policies are deployed.
+	// +optional
+	DefaultClusterwideNetworkPolicies []string `json:"defaultClusterwideNetworkPolicies,omitempty"`
+}
+
+// FirewallMonitoring contains the configuration for collecting firewall metrics in the seed
+type FirewallMonitoring struct {
+	// Enabled deploys a scrape config for the exporters of the firewalls into the shoot namespaces, such that the
+	// firewall metrics are collected by the shoot prometheus of the seed
+	Enabled bool `json:"enabled"`
+}
+
+// MachineClasses contains the configuration for the machine classes generated by the worker controller
+type MachineClasses struct {
+	// CredentialsFromSecretRefOnly omits the metal-api credentials from the machine class secrets, such that the
+	// machine-controller-manager reads them from the credentials secret ref of the machine class only
+	// +optional
+	CredentialsFromSecretRefOnly bool `json:"credentialsFromSecretRefOnly,omitempty"`
 }
 
 // NetpolsIngressController contains extra configuration for network policies regarding an ingress-controller
@@ -0,0 +1,104 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AdmissionConfiguration defines the configuration for the metal admission webhooks.
+type AdmissionConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ShootDefaults are the values the shoot mutator defaults shoots with.
+	ShootDefaults ShootDefaults `json:"shootDefaults"`
+
+	// ShootDefaultsOverrides override the shoot defaults for shoots of a cloud profile or a partition. Overrides
+	// are applied in the given order, such that later overrides take precedence over earlier ones.
+	// +optional
+	ShootDefaultsOverrides []ShootDefaultsOverride `json:"shootDefaultsOverrides,omitempty"`
+}
+
+// ShootDefaults contains the values the shoot mutator defaults shoots with.
+type ShootDefaults struct {
+	// MaxPods is the default maximum amount of pods per node.
+	// +optional
+	MaxPods *int32 `json:"maxPods,omitempty"`
+	// NodeCIDRMaskSize is the default mask size of the pod cidr of a node.
+	// +optional
+	NodeCIDRMaskSize *int32 `json:"nodeCIDRMaskSize,omitempty"`
+	// PodsCIDR is the default cidr of the pod network.
+	// +optional
+	PodsCIDR *string `json:"podsCIDR,omitempty"`
+	// ServicesCIDR is the default cidr of the service network.
+	// +optional
+	ServicesCIDR *string `json:"servicesCIDR,omitempty"`
+	// NetworkType is the default networking extension, either calico or cilium.
+	// +optional
+	NetworkType *string `json:"networkType,omitempty"`
+	// Calico contains the defaults of the calico networking extension.
+	// +optional
+	Calico *CalicoDefaults `json:"calico,omitempty"`
+	// Cilium contains the defaults of the cilium networking extension.
+	// +optional
+	Cilium *CiliumDefaults `json:"cilium,omitempty"`
+}
+
+// CalicoDefaults contains the defaults of the calico networking extension.
+type CalicoDefaults struct {
+	// Backend is the default calico backend.
+	// +optional
+	Backend *string `json:"backend,omitempty"`
+	// KubeProxyEnabled defaults whether kube-proxy is deployed alongside calico.
+	// +optional
+	KubeProxyEnabled *bool `json:"kubeProxyEnabled,omitempty"`
+	// PoolMode is the default mode of the calico ipv4 pool.
+	// +optional
+	PoolMode *string `json:"poolMode,omitempty"`
+	// TyphaEnabled defaults whether typha is deployed.
+	// +optional
+	TyphaEnabled *bool `json:"typhaEnabled,omitempty"`
+}
+
+// CiliumDefaults contains the defaults of the cilium networking extension.
+type CiliumDefaults struct {
+	// HubbleEnabled defaults whether hubble is deployed.
+	// +optional
+	HubbleEnabled *bool `json:"hubbleEnabled,omitempty"`
+	// KubeProxyEnabled defaults whether kube-proxy is deployed alongside cilium.
+	// +optional
+	KubeProxyEnabled *bool `json:"kubeProxyEnabled,omitempty"`
+	// TunnelMode is the default tunnel mode.
+	// +optional
+	TunnelMode *string `json:"tunnelMode,omitempty"`
+	// Devices are the default devices cilium attaches to.
+	// +optional
+	Devices []string `json:"devices,omitempty"`
+	// DirectRoutingDevice is the default device used for direct routing.
+	// +optional
+	DirectRoutingDevice *string `json:"directRoutingDevice,omitempty"`
+	// BGPControlPlaneEnabled defaults whether the bgp control plane is enabled.
+	// +optional
+	BGPControlPlaneEnabled *bool `json:"bgpControlPlaneEnabled,omitempty"`
+	// IPv4NativeRoutingCIDREnabled defaults whether the ipv4 native routing cidr is enabled.
+	// +optional
+	IPv4NativeRoutingCIDREnabled *bool `json:"ipv4NativeRoutingCIDREnabled,omitempty"`
+	// LoadBalancingMode is the default load balancing mode.
+	// +optional
+	LoadBalancingMode *string `json:"loadBalancingMode,omitempty"`
+	// MTU is the default mtu of the pod network.
+	// +optional
+	MTU *int32 `json:"mtu,omitempty"`
+}
+
+// ShootDefaultsOverride overrides the shoot defaults for shoots of a cloud profile or a partition.
+type ShootDefaultsOverride struct {
+	// CloudProfile is the name of the cloud profile the override applies to. Applies to all cloud profiles if empty.
+	// +optional
+	CloudProfile string `json:"cloudProfile,omitempty"`
+	// Partition is the partition the override applies to. Applies to all partitions if empty.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+	// ShootDefaults are the values which override the shoot defaults, unset values are not overridden.
+	ShootDefaults ShootDefaults `json:"shootDefaults"`
+}
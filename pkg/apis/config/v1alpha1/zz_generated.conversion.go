@@ -27,6 +27,36 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AdmissionConfiguration)(nil), (*config.AdmissionConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AdmissionConfiguration_To_config_AdmissionConfiguration(a.(*AdmissionConfiguration), b.(*config.AdmissionConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AdmissionConfiguration)(nil), (*AdmissionConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AdmissionConfiguration_To_v1alpha1_AdmissionConfiguration(a.(*config.AdmissionConfiguration), b.(*AdmissionConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CalicoDefaults)(nil), (*config.CalicoDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CalicoDefaults_To_config_CalicoDefaults(a.(*CalicoDefaults), b.(*config.CalicoDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CalicoDefaults)(nil), (*CalicoDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CalicoDefaults_To_v1alpha1_CalicoDefaults(a.(*config.CalicoDefaults), b.(*CalicoDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CiliumDefaults)(nil), (*config.CiliumDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CiliumDefaults_To_config_CiliumDefaults(a.(*CiliumDefaults), b.(*config.CiliumDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CiliumDefaults)(nil), (*CiliumDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CiliumDefaults_To_v1alpha1_CiliumDefaults(a.(*config.CiliumDefaults), b.(*CiliumDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
 	}); err != nil {
@@ -97,6 +127,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FirewallMonitoring)(nil), (*config.FirewallMonitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FirewallMonitoring_To_config_FirewallMonitoring(a.(*FirewallMonitoring), b.(*config.FirewallMonitoring), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.FirewallMonitoring)(nil), (*FirewallMonitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_FirewallMonitoring_To_v1alpha1_FirewallMonitoring(a.(*config.FirewallMonitoring), b.(*FirewallMonitoring), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ImagePullSecret)(nil), (*config.ImagePullSecret)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ImagePullSecret_To_config_ImagePullSecret(a.(*ImagePullSecret), b.(*config.ImagePullSecret), scope)
 	}); err != nil {
@@ -107,6 +147,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MachineClasses)(nil), (*config.MachineClasses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineClasses_To_config_MachineClasses(a.(*MachineClasses), b.(*config.MachineClasses), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MachineClasses)(nil), (*MachineClasses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MachineClasses_To_v1alpha1_MachineClasses(a.(*config.MachineClasses), b.(*MachineClasses), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*config.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MachineImage_To_config_MachineImage(a.(*MachineImage), b.(*config.MachineImage), scope)
 	}); err != nil {
@@ -137,6 +187,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ShootDefaults)(nil), (*config.ShootDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ShootDefaults_To_config_ShootDefaults(a.(*ShootDefaults), b.(*config.ShootDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ShootDefaults)(nil), (*ShootDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ShootDefaults_To_v1alpha1_ShootDefaults(a.(*config.ShootDefaults), b.(*ShootDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ShootDefaultsOverride)(nil), (*config.ShootDefaultsOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ShootDefaultsOverride_To_config_ShootDefaultsOverride(a.(*ShootDefaultsOverride), b.(*config.ShootDefaultsOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ShootDefaultsOverride)(nil), (*ShootDefaultsOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ShootDefaultsOverride_To_v1alpha1_ShootDefaultsOverride(a.(*config.ShootDefaultsOverride), b.(*ShootDefaultsOverride), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StorageConfiguration)(nil), (*config.StorageConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_StorageConfiguration_To_config_StorageConfiguration(a.(*StorageConfiguration), b.(*config.StorageConfiguration), scope)
 	}); err != nil {
@@ -150,6 +220,94 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_AdmissionConfiguration_To_config_AdmissionConfiguration(in *AdmissionConfiguration, out *config.AdmissionConfiguration, s conversion.Scope) error {
+	if err := Convert_v1alpha1_ShootDefaults_To_config_ShootDefaults(&in.ShootDefaults, &out.ShootDefaults, s); err != nil {
+		return err
+	}
+	out.ShootDefaultsOverrides = *(*[]config.ShootDefaultsOverride)(unsafe.Pointer(&in.ShootDefaultsOverrides))
+	return nil
+}
+
+// Convert_v1alpha1_AdmissionConfiguration_To_config_AdmissionConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_AdmissionConfiguration_To_config_AdmissionConfiguration(in *AdmissionConfiguration, out *config.AdmissionConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AdmissionConfiguration_To_config_AdmissionConfiguration(in, out, s)
+}
+
+func autoConvert_config_AdmissionConfiguration_To_v1alpha1_AdmissionConfiguration(in *config.AdmissionConfiguration, out *AdmissionConfiguration, s conversion.Scope) error {
+	if err := Convert_config_ShootDefaults_To_v1alpha1_ShootDefaults(&in.ShootDefaults, &out.ShootDefaults, s); err != nil {
+		return err
+	}
+	out.ShootDefaultsOverrides = *(*[]ShootDefaultsOverride)(unsafe.Pointer(&in.ShootDefaultsOverrides))
+	return nil
+}
+
+// Convert_config_AdmissionConfiguration_To_v1alpha1_AdmissionConfiguration is an autogenerated conversion function.
+func Convert_config_AdmissionConfiguration_To_v1alpha1_AdmissionConfiguration(in *config.AdmissionConfiguration, out *AdmissionConfiguration, s conversion.Scope) error {
+	return autoConvert_config_AdmissionConfiguration_To_v1alpha1_AdmissionConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_CalicoDefaults_To_config_CalicoDefaults(in *CalicoDefaults, out *config.CalicoDefaults, s conversion.Scope) error {
+	out.Backend = (*string)(unsafe.Pointer(in.Backend))
+	out.KubeProxyEnabled = (*bool)(unsafe.Pointer(in.KubeProxyEnabled))
+	out.PoolMode = (*string)(unsafe.Pointer(in.PoolMode))
+	out.TyphaEnabled = (*bool)(unsafe.Pointer(in.TyphaEnabled))
+	return nil
+}
+
+// Convert_v1alpha1_CalicoDefaults_To_config_CalicoDefaults is an autogenerated conversion function.
+func Convert_v1alpha1_CalicoDefaults_To_config_CalicoDefaults(in *CalicoDefaults, out *config.CalicoDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CalicoDefaults_To_config_CalicoDefaults(in, out, s)
+}
+
+func autoConvert_config_CalicoDefaults_To_v1alpha1_CalicoDefaults(in *config.CalicoDefaults, out *CalicoDefaults, s conversion.Scope) error {
+	out.Backend = (*string)(unsafe.Pointer(in.Backend))
+	out.KubeProxyEnabled = (*bool)(unsafe.Pointer(in.KubeProxyEnabled))
+	out.PoolMode = (*string)(unsafe.Pointer(in.PoolMode))
+	out.TyphaEnabled = (*bool)(unsafe.Pointer(in.TyphaEnabled))
+	return nil
+}
+
+// Convert_config_CalicoDefaults_To_v1alpha1_CalicoDefaults is an autogenerated conversion function.
+func Convert_config_CalicoDefaults_To_v1alpha1_CalicoDefaults(in *config.CalicoDefaults, out *CalicoDefaults, s conversion.Scope) error {
+	return autoConvert_config_CalicoDefaults_To_v1alpha1_CalicoDefaults(in, out, s)
+}
+
+func autoConvert_v1alpha1_CiliumDefaults_To_config_CiliumDefaults(in *CiliumDefaults, out *config.CiliumDefaults, s conversion.Scope) error {
+	out.HubbleEnabled = (*bool)(unsafe.Pointer(in.HubbleEnabled))
+	out.KubeProxyEnabled = (*bool)(unsafe.Pointer(in.KubeProxyEnabled))
+	out.TunnelMode = (*string)(unsafe.Pointer(in.TunnelMode))
+	out.Devices = *(*[]string)(unsafe.Pointer(&in.Devices))
+	out.DirectRoutingDevice = (*string)(unsafe.Pointer(in.DirectRoutingDevice))
+	out.BGPControlPlaneEnabled = (*bool)(unsafe.Pointer(in.BGPControlPlaneEnabled))
+	out.IPv4NativeRoutingCIDREnabled = (*bool)(unsafe.Pointer(in.IPv4NativeRoutingCIDREnabled))
+	out.LoadBalancingMode = (*string)(unsafe.Pointer(in.LoadBalancingMode))
+	out.MTU = (*int32)(unsafe.Pointer(in.MTU))
+	return nil
+}
+
+// Convert_v1alpha1_CiliumDefaults_To_config_CiliumDefaults is an autogenerated conversion function.
+func Convert_v1alpha1_CiliumDefaults_To_config_CiliumDefaults(in *CiliumDefaults, out *config.CiliumDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CiliumDefaults_To_config_CiliumDefaults(in, out, s)
+}
+
+func autoConvert_config_CiliumDefaults_To_v1alpha1_CiliumDefaults(in *config.CiliumDefaults, out *CiliumDefaults, s conversion.Scope) error {
+	out.HubbleEnabled = (*bool)(unsafe.Pointer(in.HubbleEnabled))
+	out.KubeProxyEnabled = (*bool)(unsafe.Pointer(in.KubeProxyEnabled))
+	out.TunnelMode = (*string)(unsafe.Pointer(in.TunnelMode))
+	out.Devices = *(*[]string)(unsafe.Pointer(&in.Devices))
+	out.DirectRoutingDevice = (*string)(unsafe.Pointer(in.DirectRoutingDevice))
+	out.BGPControlPlaneEnabled = (*bool)(unsafe.Pointer(in.BGPControlPlaneEnabled))
+	out.IPv4NativeRoutingCIDREnabled = (*bool)(unsafe.Pointer(in.IPv4NativeRoutingCIDREnabled))
+	out.LoadBalancingMode = (*string)(unsafe.Pointer(in.LoadBalancingMode))
+	out.MTU = (*int32)(unsafe.Pointer(in.MTU))
+	return nil
+}
+
+// Convert_config_CiliumDefaults_To_v1alpha1_CiliumDefaults is an autogenerated conversion function.
+func Convert_config_CiliumDefaults_To_v1alpha1_CiliumDefaults(in *config.CiliumDefaults, out *CiliumDefaults, s conversion.Scope) error {
+	return autoConvert_config_CiliumDefaults_To_v1alpha1_CiliumDefaults(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*configv1alpha1.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
 	out.MachineImages = *(*[]config.MachineImage)(unsafe.Pointer(&in.MachineImages))
@@ -164,6 +322,8 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfigurat
 	out.ImagePullPolicy = in.ImagePullPolicy
 	out.ImagePullSecret = (*config.ImagePullSecret)(unsafe.Pointer(in.ImagePullSecret))
 	out.NetworkPolicies = (*config.NetworkPolicies)(unsafe.Pointer(in.NetworkPolicies))
+	out.FirewallMonitoring = (*config.FirewallMonitoring)(unsafe.Pointer(in.FirewallMonitoring))
+	out.MachineClasses = (*config.MachineClasses)(unsafe.Pointer(in.MachineClasses))
 	return nil
 }
 
@@ -186,6 +346,8 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 	out.ImagePullPolicy = in.ImagePullPolicy
 	out.ImagePullSecret = (*ImagePullSecret)(unsafe.Pointer(in.ImagePullSecret))
 	out.NetworkPolicies = (*NetworkPolicies)(unsafe.Pointer(in.NetworkPolicies))
+	out.FirewallMonitoring = (*FirewallMonitoring)(unsafe.Pointer(in.FirewallMonitoring))
+	out.MachineClasses = (*MachineClasses)(unsafe.Pointer(in.MachineClasses))
 	return nil
 }
 
@@ -352,6 +514,26 @@ func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage,
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
 
+func autoConvert_v1alpha1_FirewallMonitoring_To_config_FirewallMonitoring(in *FirewallMonitoring, out *config.FirewallMonitoring, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1alpha1_FirewallMonitoring_To_config_FirewallMonitoring is an autogenerated conversion function.
+func Convert_v1alpha1_FirewallMonitoring_To_config_FirewallMonitoring(in *FirewallMonitoring, out *config.FirewallMonitoring, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FirewallMonitoring_To_config_FirewallMonitoring(in, out, s)
+}
+
+func autoConvert_config_FirewallMonitoring_To_v1alpha1_FirewallMonitoring(in *config.FirewallMonitoring, out *FirewallMonitoring, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_config_FirewallMonitoring_To_v1alpha1_FirewallMonitoring is an autogenerated conversion function.
+func Convert_config_FirewallMonitoring_To_v1alpha1_FirewallMonitoring(in *config.FirewallMonitoring, out *FirewallMonitoring, s conversion.Scope) error {
+	return autoConvert_config_FirewallMonitoring_To_v1alpha1_FirewallMonitoring(in, out, s)
+}
+
 func autoConvert_v1alpha1_ImagePullSecret_To_config_ImagePullSecret(in *ImagePullSecret, out *config.ImagePullSecret, s conversion.Scope) error {
 	out.DockerConfigJSON = in.DockerConfigJSON
 	return nil
@@ -372,6 +554,26 @@ func Convert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in *config.Image
 	return autoConvert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in, out, s)
 }
 
+func autoConvert_v1alpha1_MachineClasses_To_config_MachineClasses(in *MachineClasses, out *config.MachineClasses, s conversion.Scope) error {
+	out.CredentialsFromSecretRefOnly = in.CredentialsFromSecretRefOnly
+	return nil
+}
+
+// Convert_v1alpha1_MachineClasses_To_config_MachineClasses is an autogenerated conversion function.
+func Convert_v1alpha1_MachineClasses_To_config_MachineClasses(in *MachineClasses, out *config.MachineClasses, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineClasses_To_config_MachineClasses(in, out, s)
+}
+
+func autoConvert_config_MachineClasses_To_v1alpha1_MachineClasses(in *config.MachineClasses, out *MachineClasses, s conversion.Scope) error {
+	out.CredentialsFromSecretRefOnly = in.CredentialsFromSecretRefOnly
+	return nil
+}
+
+// Convert_config_MachineClasses_To_v1alpha1_MachineClasses is an autogenerated conversion function.
+func Convert_config_MachineClasses_To_v1alpha1_MachineClasses(in *config.MachineClasses, out *MachineClasses, s conversion.Scope) error {
+	return autoConvert_config_MachineClasses_To_v1alpha1_MachineClasses(in, out, s)
+}
+
 func autoConvert_v1alpha1_MachineImage_To_config_MachineImage(in *MachineImage, out *config.MachineImage, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
@@ -420,6 +622,7 @@ func Convert_config_NetpolsIngressController_To_v1alpha1_NetpolsIngressControlle
 
 func autoConvert_v1alpha1_NetworkPolicies_To_config_NetworkPolicies(in *NetworkPolicies, out *config.NetworkPolicies, s conversion.Scope) error {
 	out.IngressController = (*config.NetpolsIngressController)(unsafe.Pointer(in.IngressController))
+	out.DefaultClusterwideNetworkPolicies = *(*[]string)(unsafe.Pointer(&in.DefaultClusterwideNetworkPolicies))
 	return nil
 }
 
@@ -430,6 +633,7 @@ func Convert_v1alpha1_NetworkPolicies_To_config_NetworkPolicies(in *NetworkPolic
 
 func autoConvert_config_NetworkPolicies_To_v1alpha1_NetworkPolicies(in *config.NetworkPolicies, out *NetworkPolicies, s conversion.Scope) error {
 	out.IngressController = (*NetpolsIngressController)(unsafe.Pointer(in.IngressController))
+	out.DefaultClusterwideNetworkPolicies = *(*[]string)(unsafe.Pointer(&in.DefaultClusterwideNetworkPolicies))
 	return nil
 }
 
@@ -438,6 +642,66 @@ func Convert_config_NetworkPolicies_To_v1alpha1_NetworkPolicies(in *config.Netwo
 	return autoConvert_config_NetworkPolicies_To_v1alpha1_NetworkPolicies(in, out, s)
 }
 
+func autoConvert_v1alpha1_ShootDefaults_To_config_ShootDefaults(in *ShootDefaults, out *config.ShootDefaults, s conversion.Scope) error {
+	out.MaxPods = (*int32)(unsafe.Pointer(in.MaxPods))
+	out.NodeCIDRMaskSize = (*int32)(unsafe.Pointer(in.NodeCIDRMaskSize))
+	out.PodsCIDR = (*string)(unsafe.Pointer(in.PodsCIDR))
+	out.ServicesCIDR = (*string)(unsafe.Pointer(in.ServicesCIDR))
+	out.NetworkType = (*string)(unsafe.Pointer(in.NetworkType))
+	out.Calico = (*config.CalicoDefaults)(unsafe.Pointer(in.Calico))
+	out.Cilium = (*config.CiliumDefaults)(unsafe.Pointer(in.Cilium))
+	return nil
+}
+
+// Convert_v1alpha1_ShootDefaults_To_config_ShootDefaults is an autogenerated conversion function.
+func Convert_v1alpha1_ShootDefaults_To_config_ShootDefaults(in *ShootDefaults, out *config.ShootDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ShootDefaults_To_config_ShootDefaults(in, out, s)
+}
+
+func autoConvert_config_ShootDefaults_To_v1alpha1_ShootDefaults(in *config.ShootDefaults, out *ShootDefaults, s conversion.Scope) error {
+	out.MaxPods = (*int32)(unsafe.Pointer(in.MaxPods))
+	out.NodeCIDRMaskSize = (*int32)(unsafe.Pointer(in.NodeCIDRMaskSize))
+	out.PodsCIDR = (*string)(unsafe.Pointer(in.PodsCIDR))
+	out.ServicesCIDR = (*string)(unsafe.Pointer(in.ServicesCIDR))
+	out.NetworkType = (*string)(unsafe.Pointer(in.NetworkType))
+	out.Calico = (*CalicoDefaults)(unsafe.Pointer(in.Calico))
+	out.Cilium = (*CiliumDefaults)(unsafe.Pointer(in.Cilium))
+	return nil
+}
+
+// Convert_config_ShootDefaults_To_v1alpha1_ShootDefaults is an autogenerated conversion function.
+func Convert_config_ShootDefaults_To_v1alpha1_ShootDefaults(in *config.ShootDefaults, out *ShootDefaults, s conversion.Scope) error {
+	return autoConvert_config_ShootDefaults_To_v1alpha1_ShootDefaults(in, out, s)
+}
+
+func autoConvert_v1alpha1_ShootDefaultsOverride_To_config_ShootDefaultsOverride(in *ShootDefaultsOverride, out *config.ShootDefaultsOverride, s conversion.Scope) error {
+	out.CloudProfile = in.CloudProfile
+	out.Partition = in.Partition
+	if err := Convert_v1alpha1_ShootDefaults_To_config_ShootDefaults(&in.ShootDefaults, &out.ShootDefaults, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ShootDefaultsOverride_To_config_ShootDefaultsOverride is an autogenerated conversion function.
+func Convert_v1alpha1_ShootDefaultsOverride_To_config_ShootDefaultsOverride(in *ShootDefaultsOverride, out *config.ShootDefaultsOverride, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ShootDefaultsOverride_To_config_ShootDefaultsOverride(in, out, s)
+}
+
+func autoConvert_config_ShootDefaultsOverride_To_v1alpha1_ShootDefaultsOverride(in *config.ShootDefaultsOverride, out *ShootDefaultsOverride, s conversion.Scope) error {
+	out.CloudProfile = in.CloudProfile
+	out.Partition = in.Partition
+	if err := Convert_config_ShootDefaults_To_v1alpha1_ShootDefaults(&in.ShootDefaults, &out.ShootDefaults, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_ShootDefaultsOverride_To_v1alpha1_ShootDefaultsOverride is an autogenerated conversion function.
+func Convert_config_ShootDefaultsOverride_To_v1alpha1_ShootDefaultsOverride(in *config.ShootDefaultsOverride, out *ShootDefaultsOverride, s conversion.Scope) error {
+	return autoConvert_config_ShootDefaultsOverride_To_v1alpha1_ShootDefaultsOverride(in, out, s)
+}
+
 func autoConvert_v1alpha1_StorageConfiguration_To_config_StorageConfiguration(in *StorageConfiguration, out *config.StorageConfiguration, s conversion.Scope) error {
 	if err := Convert_v1alpha1_DurosConfiguration_To_config_DurosConfiguration(&in.Duros, &out.Duros, s); err != nil {
 		return err
@@ -15,6 +15,136 @@ import (
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionConfiguration) DeepCopyInto(out *AdmissionConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ShootDefaults.DeepCopyInto(&out.ShootDefaults)
+	if in.ShootDefaultsOverrides != nil {
+		in, out := &in.ShootDefaultsOverrides, &out.ShootDefaultsOverrides
+		*out = make([]ShootDefaultsOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionConfiguration.
+func (in *AdmissionConfiguration) DeepCopy() *AdmissionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AdmissionConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoDefaults) DeepCopyInto(out *CalicoDefaults) {
+	*out = *in
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(string)
+		**out = **in
+	}
+	if in.KubeProxyEnabled != nil {
+		in, out := &in.KubeProxyEnabled, &out.KubeProxyEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PoolMode != nil {
+		in, out := &in.PoolMode, &out.PoolMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.TyphaEnabled != nil {
+		in, out := &in.TyphaEnabled, &out.TyphaEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoDefaults.
+func (in *CalicoDefaults) DeepCopy() *CalicoDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumDefaults) DeepCopyInto(out *CiliumDefaults) {
+	*out = *in
+	if in.HubbleEnabled != nil {
+		in, out := &in.HubbleEnabled, &out.HubbleEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KubeProxyEnabled != nil {
+		in, out := &in.KubeProxyEnabled, &out.KubeProxyEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TunnelMode != nil {
+		in, out := &in.TunnelMode, &out.TunnelMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DirectRoutingDevice != nil {
+		in, out := &in.DirectRoutingDevice, &out.DirectRoutingDevice
+		*out = new(string)
+		**out = **in
+	}
+	if in.BGPControlPlaneEnabled != nil {
+		in, out := &in.BGPControlPlaneEnabled, &out.BGPControlPlaneEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IPv4NativeRoutingCIDREnabled != nil {
+		in, out := &in.IPv4NativeRoutingCIDREnabled, &out.IPv4NativeRoutingCIDREnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LoadBalancingMode != nil {
+		in, out := &in.LoadBalancingMode, &out.LoadBalancingMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumDefaults.
+func (in *CiliumDefaults) DeepCopy() *CiliumDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
@@ -51,6 +181,16 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(NetworkPolicies)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FirewallMonitoring != nil {
+		in, out := &in.FirewallMonitoring, &out.FirewallMonitoring
+		*out = new(FirewallMonitoring)
+		**out = **in
+	}
+	if in.MachineClasses != nil {
+		in, out := &in.MachineClasses, &out.MachineClasses
+		*out = new(MachineClasses)
+		**out = **in
+	}
 	return
 }
 
@@ -207,6 +347,22 @@ func (in *ETCDStorage) DeepCopy() *ETCDStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallMonitoring) DeepCopyInto(out *FirewallMonitoring) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallMonitoring.
+func (in *FirewallMonitoring) DeepCopy() *FirewallMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
 	*out = *in
@@ -223,6 +379,22 @@ func (in *ImagePullSecret) DeepCopy() *ImagePullSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineClasses) DeepCopyInto(out *MachineClasses) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineClasses.
+func (in *MachineClasses) DeepCopy() *MachineClasses {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineClasses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineImage) DeepCopyInto(out *MachineImage) {
 	*out = *in
@@ -270,6 +442,11 @@ func (in *NetworkPolicies) DeepCopyInto(out *NetworkPolicies) {
 		*out = new(NetpolsIngressController)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultClusterwideNetworkPolicies != nil {
+		in, out := &in.DefaultClusterwideNetworkPolicies, &out.DefaultClusterwideNetworkPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -283,6 +460,74 @@ func (in *NetworkPolicies) DeepCopy() *NetworkPolicies {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShootDefaults) DeepCopyInto(out *ShootDefaults) {
+	*out = *in
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeCIDRMaskSize != nil {
+		in, out := &in.NodeCIDRMaskSize, &out.NodeCIDRMaskSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodsCIDR != nil {
+		in, out := &in.PodsCIDR, &out.PodsCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServicesCIDR != nil {
+		in, out := &in.ServicesCIDR, &out.ServicesCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkType != nil {
+		in, out := &in.NetworkType, &out.NetworkType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Calico != nil {
+		in, out := &in.Calico, &out.Calico
+		*out = new(CalicoDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cilium != nil {
+		in, out := &in.Cilium, &out.Cilium
+		*out = new(CiliumDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShootDefaults.
+func (in *ShootDefaults) DeepCopy() *ShootDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ShootDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShootDefaultsOverride) DeepCopyInto(out *ShootDefaultsOverride) {
+	*out = *in
+	in.ShootDefaults.DeepCopyInto(&out.ShootDefaults)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShootDefaultsOverride.
+func (in *ShootDefaultsOverride) DeepCopy() *ShootDefaultsOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ShootDefaultsOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageConfiguration) DeepCopyInto(out *StorageConfiguration) {
 	*out = *in
@@ -17,5 +17,10 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&AdmissionConfiguration{}, func(obj interface{}) { SetObjectDefaults_AdmissionConfiguration(obj.(*AdmissionConfiguration)) })
 	return nil
 }
+
+func SetObjectDefaults_AdmissionConfiguration(in *AdmissionConfiguration) {
+	SetDefaults_AdmissionConfiguration(in)
+}
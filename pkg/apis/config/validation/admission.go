@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	minimumMTU = 1280
+	maximumMTU = 9216
+)
+
+var (
+	supportedNetworkTypes             = sets.New("calico", "cilium")
+	supportedCalicoBackends           = sets.New("bird", "vxlan", "none")
+	supportedCalicoPoolModes          = sets.New("Always", "CrossSubnet", "Never")
+	supportedCiliumTunnelModes        = sets.New("vxlan", "geneve", "disabled")
+	supportedCiliumLoadBalancingModes = sets.New("snat", "dsr", "hybrid")
+)
+
+// ValidateAdmissionConfiguration validates the given admission configuration.
+func ValidateAdmissionConfiguration(cfg *config.AdmissionConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateShootDefaults(&cfg.ShootDefaults, field.NewPath("shootDefaults"))...)
+
+	for i, override := range cfg.ShootDefaultsOverrides {
+		idxPath := field.NewPath("shootDefaultsOverrides").Index(i)
+
+		if override.CloudProfile == "" && override.Partition == "" {
+			allErrs = append(allErrs, field.Required(idxPath, "either a cloud profile or a partition must be given"))
+		}
+
+		allErrs = append(allErrs, validateShootDefaults(&override.ShootDefaults, idxPath.Child("shootDefaults"))...)
+	}
+
+	return allErrs
+}
+
+func validateShootDefaults(d *config.ShootDefaults, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if d.MaxPods != nil && *d.MaxPods <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPods"), *d.MaxPods, "must be greater than 0"))
+	}
+	if d.NodeCIDRMaskSize != nil && (*d.NodeCIDRMaskSize < 1 || *d.NodeCIDRMaskSize > 32) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeCIDRMaskSize"), *d.NodeCIDRMaskSize, "must be between 1 and 32"))
+	}
+	allErrs = append(allErrs, validatePrefix(d.PodsCIDR, fldPath.Child("podsCIDR"))...)
+	allErrs = append(allErrs, validatePrefix(d.ServicesCIDR, fldPath.Child("servicesCIDR"))...)
+	allErrs = append(allErrs, validateSupported(d.NetworkType, supportedNetworkTypes, fldPath.Child("networkType"))...)
+
+	if d.Calico != nil {
+		calicoPath := fldPath.Child("calico")
+		allErrs = append(allErrs, validateSupported(d.Calico.Backend, supportedCalicoBackends, calicoPath.Child("backend"))...)
+		allErrs = append(allErrs, validateSupported(d.Calico.PoolMode, supportedCalicoPoolModes, calicoPath.Child("poolMode"))...)
+	}
+
+	if d.Cilium != nil {
+		ciliumPath := fldPath.Child("cilium")
+		allErrs = append(allErrs, validateSupported(d.Cilium.TunnelMode, supportedCiliumTunnelModes, ciliumPath.Child("tunnelMode"))...)
+		allErrs = append(allErrs, validateSupported(d.Cilium.LoadBalancingMode, supportedCiliumLoadBalancingModes, ciliumPath.Child("loadBalancingMode"))...)
+
+		if d.Cilium.MTU != nil && (*d.Cilium.MTU < minimumMTU || *d.Cilium.MTU > maximumMTU) {
+			allErrs = append(allErrs, field.Invalid(ciliumPath.Child("mtu"), *d.Cilium.MTU, fmt.Sprintf("must be between %d and %d", minimumMTU, maximumMTU)))
+		}
+	}
+
+	return allErrs
+}
+
+func validatePrefix(prefix *string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if prefix == nil {
+		return allErrs
+	}
+
+	if _, err := netip.ParsePrefix(*prefix); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, *prefix, "must be a valid cidr"))
+	}
+
+	return allErrs
+}
+
+func validateSupported(value *string, supported sets.Set[string], fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value != nil && !supported.Has(*value) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, *value, sets.List(supported)))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,105 @@
+package validation_test
+
+import (
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+
+	. "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("AdmissionConfiguration validation", func() {
+	Describe("#ValidateAdmissionConfiguration", func() {
+		var cfg *config.AdmissionConfiguration
+
+		BeforeEach(func() {
+			cfg = &config.AdmissionConfiguration{
+				ShootDefaults: config.ShootDefaults{
+					MaxPods:          new(int32(250)),
+					NodeCIDRMaskSize: new(int32(23)),
+					PodsCIDR:         new("10.240.0.0/13"),
+					ServicesCIDR:     new("10.248.0.0/18"),
+					NetworkType:      new("calico"),
+					Calico: &config.CalicoDefaults{
+						Backend:  new("none"),
+						PoolMode: new("Never"),
+					},
+					Cilium: &config.CiliumDefaults{
+						TunnelMode:        new("disabled"),
+						LoadBalancingMode: new("dsr"),
+						MTU:               new(int32(1440)),
+					},
+				},
+				ShootDefaultsOverrides: []config.ShootDefaultsOverride{
+					{
+						Partition: "partition-a",
+						ShootDefaults: config.ShootDefaults{
+							NodeCIDRMaskSize: new(int32(24)),
+						},
+					},
+				},
+			}
+		})
+
+		It("should pass a valid configuration", func() {
+			Expect(ValidateAdmissionConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should allow all calico pool modes", func() {
+			for _, mode := range []string{"Always", "CrossSubnet", "Never"} {
+				cfg.ShootDefaults.Calico.PoolMode = new(mode)
+
+				Expect(ValidateAdmissionConfiguration(cfg)).To(BeEmpty())
+			}
+		})
+
+		It("should forbid invalid shoot defaults", func() {
+			cfg.ShootDefaults.PodsCIDR = new("10.240.0.0")
+			cfg.ShootDefaults.NetworkType = new("flannel")
+			cfg.ShootDefaults.Cilium.MTU = new(int32(100))
+
+			Expect(ValidateAdmissionConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("shootDefaults.podsCIDR"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("shootDefaults.networkType"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("shootDefaults.cilium.mtu"),
+					"Detail": Equal("must be between 1280 and 9216"),
+				})),
+			))
+		})
+
+		It("should require a cloud profile or a partition for overrides", func() {
+			cfg.ShootDefaultsOverrides[0].Partition = ""
+
+			Expect(ValidateAdmissionConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("shootDefaultsOverrides[0]"),
+				})),
+			))
+		})
+
+		It("should validate the overridden shoot defaults", func() {
+			cfg.ShootDefaultsOverrides[0].ShootDefaults.Calico = &config.CalicoDefaults{
+				Backend: new("ipip"),
+			}
+
+			Expect(ValidateAdmissionConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("shootDefaultsOverrides[0].shootDefaults.calico.backend"),
+				})),
+			))
+		})
+	})
+})
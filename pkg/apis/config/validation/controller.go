@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateControllerConfiguration validates the given controller configuration.
+func ValidateControllerConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.NetworkPolicies != nil {
+		policiesPath := field.NewPath("networkPolicies", "defaultClusterwideNetworkPolicies")
+		supported := sets.New(helper.AllDefaultNetworkPolicies...)
+
+		seen := sets.New[string]()
+		for i, name := range cfg.NetworkPolicies.DefaultClusterwideNetworkPolicies {
+			if !supported.Has(name) {
+				allErrs = append(allErrs, field.NotSupported(policiesPath.Index(i), name, sets.List(supported)))
+				continue
+			}
+			if seen.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(policiesPath.Index(i), name))
+			}
+			seen.Insert(name)
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,47 @@
+package validation_test
+
+import (
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+
+	. "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ControllerConfiguration validation", func() {
+	Describe("#ValidateControllerConfiguration", func() {
+		var cfg *config.ControllerConfiguration
+
+		BeforeEach(func() {
+			cfg = &config.ControllerConfiguration{
+				NetworkPolicies: &config.NetworkPolicies{
+					DefaultClusterwideNetworkPolicies: []string{"allow-to-dns", "allow-to-ntp"},
+				},
+			}
+		})
+
+		It("should pass a valid configuration", func() {
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should forbid unknown and duplicate default network policies", func() {
+			cfg.NetworkPolicies.DefaultClusterwideNetworkPolicies = []string{"allow-to-dns", "allow-to-everything", "allow-to-dns"}
+
+			errorList := ValidateControllerConfiguration(cfg)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networkPolicies.defaultClusterwideNetworkPolicies[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networkPolicies.defaultClusterwideNetworkPolicies[2]"),
+				})),
+			))
+		})
+	})
+})
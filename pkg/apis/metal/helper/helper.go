@@ -2,6 +2,10 @@ package helper
 
 import (
 	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
@@ -11,6 +15,24 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// DefaultDNSPort is the port at which dns servers are reachable.
+	DefaultDNSPort = int32(53)
+	// DefaultNTPPort is the port at which ntp servers are reachable.
+	DefaultNTPPort = int32(123)
+	// DefaultImageRegistry is the registry from which images without registry host are pulled.
+	DefaultImageRegistry = "docker.io"
+)
+
+// AllDefaultNetworkPolicies are the names of the default cluster-wide network policies which can be deployed into a shoot.
+var AllDefaultNetworkPolicies = []string{
+	metal.DefaultNetworkPolicyAllowToDNS,
+	metal.DefaultNetworkPolicyAllowToNTP,
+	metal.DefaultNetworkPolicyAllowToVPN,
+	metal.DefaultNetworkPolicyAllowToHTTP,
+	metal.DefaultNetworkPolicyAllowToHTTPS,
+}
+
 // FindMachineImage takes a list of machine images and tries to find the first entry
 // whose name, version, and zone matches with the given name, version, and zone. If no such entry is
 // found then an error will be returned.
@@ -63,3 +85,104 @@ func GetNodeCIDR(infrastructure *extensionsv1alpha1.Infrastructure, cluster *ext
 
 	return nodeCIDR, nil
 }
+
+// NetworkServerProtocols returns the protocol of a network server or the given default protocols if no protocol is given.
+func NetworkServerProtocols(server metal.NetworkServer, defaultProtocols ...metal.NetworkServerProtocol) []metal.NetworkServerProtocol {
+	if server.Protocol == "" {
+		return defaultProtocols
+	}
+	return []metal.NetworkServerProtocol{server.Protocol}
+}
+
+// ImageRegistry returns the registry host of the given image reference.
+func ImageRegistry(image string) string {
+	host, _, found := strings.Cut(image, "/")
+	if !found || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return DefaultImageRegistry
+	}
+	if host == "index.docker.io" || host == "registry-1.docker.io" {
+		return DefaultImageRegistry
+	}
+	return host
+}
+
+// UncoveredImages returns the sorted list of images whose registry is not served by any of the given registry mirrors.
+func UncoveredImages(mirrors []metal.RegistryMirror, images []string) []string {
+	var covered []string
+	for _, m := range mirrors {
+		covered = append(covered, m.MirrorOf...)
+		if u, err := url.Parse(m.Endpoint); err == nil && u.Host != "" {
+			covered = append(covered, u.Host)
+		}
+	}
+
+	var result []string
+	for _, image := range images {
+		if slices.Contains(covered, ImageRegistry(image)) {
+			continue
+		}
+		result = append(result, image)
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// IPsOutsideOf returns the given ips which are not contained in any of the given cidrs. Unparsable ips and cidrs are
+// ignored.
+func IPsOutsideOf(ips []string, cidrs []string) []string {
+	var prefixes []netip.Prefix
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	var result []string
+	for _, s := range ips {
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			continue
+		}
+
+		if !slices.ContainsFunc(prefixes, func(prefix netip.Prefix) bool {
+			return prefix.Contains(ip)
+		}) {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// DefaultNetworkPolicies returns the default cluster-wide network policies which are deployed into a shoot.
+// If no seed defaults are given, all default policies are considered. For restricted or forbidden clusters the defaults
+// of the partition's network isolation take precedence over the seed defaults. Policies disabled in the control plane
+// config are omitted. The vpn policy is always deployed as the shoot cannot connect to its control plane without it.
+func DefaultNetworkPolicies(seedDefaults []string, partition *metal.Partition, cpConfig *metal.ControlPlaneConfig) []string {
+	defaults := AllDefaultNetworkPolicies
+	if len(seedDefaults) > 0 {
+		defaults = seedDefaults
+	}
+
+	isolated := cpConfig.NetworkAccessType != nil && *cpConfig.NetworkAccessType != metal.NetworkAccessBaseline
+	if isolated && partition != nil && partition.NetworkIsolation != nil && partition.NetworkIsolation.DefaultNetworkPolicies != nil {
+		defaults = partition.NetworkIsolation.DefaultNetworkPolicies
+	}
+
+	var result []string
+	for _, policy := range defaults {
+		if slices.Contains(cpConfig.DisabledDefaultNetworkPolicies, policy) {
+			continue
+		}
+		result = append(result, policy)
+	}
+
+	if !slices.Contains(result, metal.DefaultNetworkPolicyAllowToVPN) {
+		result = append(result, metal.DefaultNetworkPolicyAllowToVPN)
+	}
+
+	return result
+}
@@ -0,0 +1,184 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+)
+
+func TestImageRegistry(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx", want: "docker.io"},
+		{image: "nginx:1.27", want: "docker.io"},
+		{image: "library/nginx:1.27", want: "docker.io"},
+		{image: "index.docker.io/library/nginx", want: "docker.io"},
+		{image: "ghcr.io/metal-stack/firewall-controller-manager:v0.6.1", want: "ghcr.io"},
+		{image: "registry.local:5000/image@sha256:abc", want: "registry.local:5000"},
+		{image: "localhost/image", want: "localhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := ImageRegistry(tt.image); got != tt.want {
+				t.Errorf("ImageRegistry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUncoveredImages(t *testing.T) {
+	mirrors := []metal.RegistryMirror{
+		{
+			Name:     "metal-stack registry",
+			Endpoint: "https://r.metal-stack.dev",
+			MirrorOf: []string{"ghcr.io", "docker.io"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mirrors []metal.RegistryMirror
+		images  []string
+		want    []string
+	}{
+		{
+			name:    "all images covered",
+			mirrors: mirrors,
+			images:  []string{"ghcr.io/metal-stack/droptailer:v0.2", "nginx", "r.metal-stack.dev/metal-stack/metallb:v0.13"},
+			want:    nil,
+		},
+		{
+			name:    "uncovered images are returned sorted and without duplicates",
+			mirrors: mirrors,
+			images:  []string{"quay.io/cilium/cilium:v1.15", "ghcr.io/metal-stack/droptailer:v0.2", "europe-docker.pkg.dev/gardener-project/releases/pause:3.9", "quay.io/cilium/cilium:v1.15"},
+			want:    []string{"europe-docker.pkg.dev/gardener-project/releases/pause:3.9", "quay.io/cilium/cilium:v1.15"},
+		},
+		{
+			name:    "no mirrors",
+			mirrors: nil,
+			images:  []string{"nginx"},
+			want:    []string{"nginx"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UncoveredImages(tt.mirrors, tt.images)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UncoveredImages() diff = %s", diff)
+			}
+		})
+	}
+}
+
+func TestIPsOutsideOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		ips   []string
+		cidrs []string
+		want  []string
+	}{
+		{
+			name:  "all ips contained",
+			ips:   []string{"10.0.0.1", "2001:db8::1"},
+			cidrs: []string{"10.0.0.0/16", "2001:db8::/32"},
+			want:  nil,
+		},
+		{
+			name:  "ips outside of the cidrs are returned",
+			ips:   []string{"10.0.0.1", "10.2.0.1", "2001:db9::1"},
+			cidrs: []string{"10.0.0.0/16", "2001:db8::/32"},
+			want:  []string{"10.2.0.1", "2001:db9::1"},
+		},
+		{
+			name:  "invalid ips and cidrs are ignored",
+			ips:   []string{"10.0.0.1", "invalid"},
+			cidrs: []string{"invalid", "10.0.0.0/16"},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IPsOutsideOf(tt.ips, tt.cidrs)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("IPsOutsideOf() diff = %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultNetworkPolicies(t *testing.T) {
+	partition := &metal.Partition{
+		NetworkIsolation: &metal.NetworkIsolation{
+			DefaultNetworkPolicies: []string{metal.DefaultNetworkPolicyAllowToDNS, metal.DefaultNetworkPolicyAllowToVPN},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		seedDefaults []string
+		partition    *metal.Partition
+		cpConfig     *metal.ControlPlaneConfig
+		want         []string
+	}{
+		{
+			name:     "all defaults",
+			cpConfig: &metal.ControlPlaneConfig{},
+			want:     AllDefaultNetworkPolicies,
+		},
+		{
+			name:         "seed defaults",
+			seedDefaults: []string{metal.DefaultNetworkPolicyAllowToDNS, metal.DefaultNetworkPolicyAllowToNTP, metal.DefaultNetworkPolicyAllowToVPN},
+			partition:    partition,
+			cpConfig:     &metal.ControlPlaneConfig{},
+			want:         []string{metal.DefaultNetworkPolicyAllowToDNS, metal.DefaultNetworkPolicyAllowToNTP, metal.DefaultNetworkPolicyAllowToVPN},
+		},
+		{
+			name:         "partition defaults take precedence for isolated clusters",
+			seedDefaults: []string{metal.DefaultNetworkPolicyAllowToDNS, metal.DefaultNetworkPolicyAllowToNTP, metal.DefaultNetworkPolicyAllowToVPN},
+			partition:    partition,
+			cpConfig: &metal.ControlPlaneConfig{
+				NetworkAccessType: new(metal.NetworkAccessRestricted),
+			},
+			want: []string{metal.DefaultNetworkPolicyAllowToDNS, metal.DefaultNetworkPolicyAllowToVPN},
+		},
+		{
+			name:         "empty seed defaults deploy all defaults",
+			seedDefaults: []string{},
+			cpConfig:     &metal.ControlPlaneConfig{},
+			want:         AllDefaultNetworkPolicies,
+		},
+		{
+			name:         "vpn policy cannot be removed by the seed or the partition",
+			seedDefaults: []string{metal.DefaultNetworkPolicyAllowToDNS},
+			partition: &metal.Partition{
+				NetworkIsolation: &metal.NetworkIsolation{
+					DefaultNetworkPolicies: []string{metal.DefaultNetworkPolicyAllowToNTP},
+				},
+			},
+			cpConfig: &metal.ControlPlaneConfig{
+				NetworkAccessType: new(metal.NetworkAccessRestricted),
+			},
+			want: []string{metal.DefaultNetworkPolicyAllowToNTP, metal.DefaultNetworkPolicyAllowToVPN},
+		},
+		{
+			name:      "disabled policies are omitted",
+			partition: partition,
+			cpConfig: &metal.ControlPlaneConfig{
+				NetworkAccessType:              new(metal.NetworkAccessForbidden),
+				DisabledDefaultNetworkPolicies: []string{metal.DefaultNetworkPolicyAllowToDNS},
+			},
+			want: []string{metal.DefaultNetworkPolicyAllowToVPN},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultNetworkPolicies(tt.seedDefaults, tt.partition, tt.cpConfig)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
@@ -56,6 +56,37 @@ func InfrastructureConfigFromInfrastructure(infra *extensionsv1alpha1.Infrastruc
 	return nil, fmt.Errorf("provider config is not set on the infrastructure resource")
 }
 
+// InfrastructureStatusFromInfrastructure extracts the InfrastructureStatus from the
+// ProviderStatus section of the given Infrastructure.
+func InfrastructureStatusFromInfrastructure(infra *extensionsv1alpha1.Infrastructure) (*api.InfrastructureStatus, error) {
+	status := &api.InfrastructureStatus{}
+	if infra.Status.ProviderStatus != nil && infra.Status.ProviderStatus.Raw != nil {
+		if _, _, err := decoder.Decode(infra.Status.ProviderStatus.Raw, nil, status); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// AppliedNetworkAccessType returns the network access type which is applied to the firewall of a cluster according to
+// the status of its worker. The desired type is returned as long as the worker does not report a network access type.
+func AppliedNetworkAccessType(worker *extensionsv1alpha1.Worker, desired api.NetworkAccessType) (api.NetworkAccessType, error) {
+	if worker == nil || worker.Status.ProviderStatus == nil || worker.Status.ProviderStatus.Raw == nil {
+		return desired, nil
+	}
+
+	status := &api.WorkerStatus{}
+	if _, _, err := decoder.Decode(worker.Status.ProviderStatus.Raw, nil, status); err != nil {
+		return "", fmt.Errorf("could not decode WorkerStatus '%s' %w", worker.Name, err)
+	}
+
+	if status.NetworkAccess == nil {
+		return desired, nil
+	}
+
+	return status.NetworkAccess.Type, nil
+}
+
 // ControlPlaneConfigFromControlPlane extracts the ControlPlaneConfig from the
 // ProviderConfig section of the given ControlPlane.
 func ControlPlaneConfigFromControlPlane(cp *extensionsv1alpha1.ControlPlane) (*api.ControlPlaneConfig, error) {
@@ -81,6 +112,25 @@ func ControlPlaneConfigFromClusterShootSpec(cluster *controller.Cluster) (*api.C
 	return config, nil
 }
 
+// InfrastructureConfigFromClusterShootSpec extracts the InfrastructureConfig from the shoot spec of a given cluster.
+func InfrastructureConfigFromClusterShootSpec(cluster *controller.Cluster) (*api.InfrastructureConfig, error) {
+	if cluster == nil {
+		return &api.InfrastructureConfig{}, nil
+	}
+	return InfrastructureConfigFromShoot(cluster.Shoot)
+}
+
+// InfrastructureConfigFromShoot extracts the InfrastructureConfig from the spec of a given shoot.
+func InfrastructureConfigFromShoot(shoot *gardencorev1beta1.Shoot) (*api.InfrastructureConfig, error) {
+	config := &api.InfrastructureConfig{}
+	if shoot != nil && shoot.Spec.Provider.InfrastructureConfig != nil && shoot.Spec.Provider.InfrastructureConfig.Raw != nil {
+		if _, _, err := decoder.Decode(shoot.Spec.Provider.InfrastructureConfig.Raw, nil, config); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
 // CloudProfileConfigFromCluster decodes the provider specific cloud profile configuration for a cluster
 func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfileConfig, error) {
 	var cloudProfileConfig *api.CloudProfileConfig
@@ -93,6 +143,17 @@ func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfi
 	return cloudProfileConfig, nil
 }
 
+// WorkerConfigFromRawExtension decodes the provider specific configuration of a worker pool
+func WorkerConfigFromRawExtension(providerConfig *runtime.RawExtension) (*api.WorkerConfig, error) {
+	config := &api.WorkerConfig{}
+	if providerConfig != nil && providerConfig.Raw != nil {
+		if _, _, err := decoder.Decode(providerConfig.Raw, nil, config); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
 // DecodeRawExtension decodes a raw extension into an object
 func DecodeRawExtension[T runtime.Object](extension *runtime.RawExtension, object T, decoder runtime.Decoder) error {
 	if extension != nil && extension.Raw != nil {
@@ -23,10 +23,26 @@ type MetalControlPlane struct {
 	Partitions map[string]Partition
 	// FirewallImages is a list of available firewall images in this control plane. When empty, allows all values.
 	FirewallImages []string
+	// FirewallImageClassifications maps firewall images to their classification. Preview and deprecated images are not
+	// chosen as the default firewall image of new shoots. Images which are not classified are considered supported.
+	FirewallImageClassifications map[string]VersionClassification
 	// FirewallControllerVersions is a list of available firewall controller binary versions
 	FirewallControllerVersions []FirewallControllerVersion
 	// NftablesExporter is the nftables exporter which will be reconciled by the firewall controller
 	NftablesExporter NftablesExporter
+	// ValidateNetworks enables the validation of the firewall networks and the additional networks of the worker pools
+	// of a shoot against the metal-api during admission. The admission component requires access to the metal-api for
+	// this purpose. The networks of shoots whose credentials binding references a workload identity are not validated,
+	// as the tokens of workload identities are only issued into the seed. Shoots with a restricted or forbidden network
+	// access type can only use additional networks for their worker pools if they are validated.
+	ValidateNetworks *bool
+	// ValidateCredentials enables the validation of the credentials referenced by secret and credentials bindings
+	// against the metal-api during admission. It also validates that the project of a shoot belongs to its tenant and
+	// is accessible with its credentials. The admission component requires access to the metal-api for this purpose.
+	// The credentials of a binding are validated against the metal control planes of the shoots which use it, so the
+	// credentials of a new binding are only validated on the admission of the first shoot which uses it. The project
+	// of shoots whose credentials binding references a workload identity is not validated.
+	ValidateCredentials *bool
 }
 
 // FirewallControllerVersion describes the version of the firewall controller binary
@@ -70,18 +86,45 @@ type Partition struct {
 	// NetworkIsolation if given allows the creation of shoot clusters which have network restrictions activated.
 	// Will be taken into account if NetworkAccessRestricted or NetworkAccessForbidden is defined
 	NetworkIsolation *NetworkIsolation
+
+	// MaxRateLimit is the maximum rate limit in Mbit/s which can be configured for a firewall network in this partition.
+	MaxRateLimit *uint32
+
+	// FirewallDefaulting is the policy by which the firewall of shoots in this partition is defaulted.
+	FirewallDefaulting *FirewallDefaulting
+
+	// DefaultExternalNetworks are the external networks which are added to the firewall of shoots in this partition
+	// when the shoot does not define any firewall networks.
+	DefaultExternalNetworks []string
+}
+
+// FirewallDefaulting is the policy by which the firewall of shoots is defaulted.
+type FirewallDefaulting struct {
+	// Size is the default firewall size. If not set, the first firewall type of the partition is used.
+	Size *string
+	// ImageOS is the preferred operating system of the default firewall image, e.g. firewall-ubuntu. If not set, the
+	// newest image of any operating system is used.
+	ImageOS *string
 }
 
 // NetworkIsolation defines configuration for restricted or forbidden clusters.
 type NetworkIsolation struct {
 	// AllowedNetworks is a list of networks which are allowed to connect in restricted or forbidden NetworkIsolated clusters.
 	AllowedNetworks AllowedNetworks
-	// DNSServers
-	DNSServers []string
-	// NTPServers
-	NTPServers []string
+	// ApprovableNetworks is a list of networks which can be added to the allowed networks of a restricted or forbidden
+	// NetworkIsolated cluster through the additional allowed networks of the control plane config.
+	// +optional
+	ApprovableNetworks *AllowedNetworks
+	// DNSServers are the dns servers which are reachable from restricted or forbidden NetworkIsolated clusters.
+	DNSServers []NetworkServer
+	// NTPServers are the ntp servers which are reachable from restricted or forbidden NetworkIsolated clusters.
+	NTPServers []NetworkServer
 	// The registry which serves the images required to create a shoot.
 	RegistryMirrors []RegistryMirror
+	// DefaultNetworkPolicies is the list of default cluster-wide network policies which are deployed into restricted or
+	// forbidden NetworkIsolated clusters. If not set, the defaults of the seed are used.
+	// +optional
+	DefaultNetworkPolicies []string
 }
 
 // AllowedNetworks is a list of networks which are allowed to connect in restricted or forbidden NetworkIsolated clusters.
@@ -94,6 +137,24 @@ type AllowedNetworks struct {
 	Egress []string
 }
 
+// NetworkServer describes the endpoint of a network service like dns or ntp.
+type NetworkServer struct {
+	// IP is the ipv4 or ipv6 address of this server
+	IP string
+	// Protocol over which the service is reachable, the default protocols of the service are used if unset
+	Protocol NetworkServerProtocol
+}
+
+// NetworkServerProtocol is the transport protocol of a network server.
+type NetworkServerProtocol string
+
+const (
+	// NetworkServerProtocolTCP describes a network server reachable over tcp.
+	NetworkServerProtocolTCP = NetworkServerProtocol("TCP")
+	// NetworkServerProtocolUDP describes a network server reachable over udp.
+	NetworkServerProtocolUDP = NetworkServerProtocol("UDP")
+)
+
 type RegistryMirror struct {
 	// Name describes this server
 	Name string
@@ -1,6 +1,7 @@
 package metal
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,11 +24,77 @@ type ControlPlaneConfig struct {
 	// NetworkAccessType defines how the cluster can reach external networks.
 	// +optional
 	NetworkAccessType *NetworkAccessType
+
+	// StaticIPReservations contains named static ips which are allocated for the cluster in external networks.
+	// The ips are made available to the cloud-controller-manager for services of type load balancer.
+	// +optional
+	StaticIPReservations []StaticIPReservation
+
+	// AdditionalAllowedNetworks are networks which are allowed to be reached from a restricted or forbidden cluster
+	// in addition to the allowed networks of the partition.
+	// The networks must be contained in the approvable networks of the partition's network isolation.
+	// +optional
+	AdditionalAllowedNetworks *AllowedNetworks
+
+	// NetworkPolicies are cluster-wide network policies which are deployed into the shoot in addition to the default
+	// policies of the cluster. They allow shipping a shoot with a policy baseline from the beginning.
+	// For clusters with network access type forbidden, the networks must be contained in the allowed networks.
+	// +optional
+	NetworkPolicies []NetworkPolicy
+
+	// DisabledDefaultNetworkPolicies is a list of default cluster-wide network policies which are not deployed into the shoot.
+	// If the default policies for dns or ntp are disabled, the servers must be reachable through the network policies
+	// of this config.
+	// +optional
+	DisabledDefaultNetworkPolicies []string
+}
+
+// NetworkPolicy declares a cluster-wide network policy which is deployed into the shoot.
+type NetworkPolicy struct {
+	// Name is the name of the policy, it must be unique within the cluster.
+	Name string
+	// Egress is a list of rules which allow outgoing traffic.
+	// +optional
+	Egress []NetworkPolicyRule
+	// Ingress is a list of rules which allow incoming traffic.
+	// +optional
+	Ingress []NetworkPolicyRule
+}
+
+// NetworkPolicyRule allows traffic to or from the given networks.
+type NetworkPolicyRule struct {
+	// CIDRs are the networks to which (egress) or from which (ingress) traffic is allowed.
+	CIDRs []string
+	// Ports restricts the traffic to the given ports, if empty all ports are allowed.
+	// +optional
+	Ports []NetworkPolicyPort
+}
+
+// NetworkPolicyPort describes a port of a network policy rule.
+type NetworkPolicyPort struct {
+	// Protocol is the transport protocol, either TCP or UDP.
+	Protocol corev1.Protocol
+	// Port is the port number.
+	Port int32
+}
+
+// StaticIPReservation declares a named static ip that is reserved for the cluster.
+type StaticIPReservation struct {
+	// Name is the name of the reservation, it must be unique within the cluster.
+	Name string
+	// Network is the id of the external network from which the ip is allocated.
+	Network string
+	// Ephemeral defines that the ip is released when the cluster is deleted.
+	// By default, the ip is kept in the project and only the cluster tags are removed from it.
+	// +optional
+	Ephemeral bool
 }
 
 // CustomDefaultStorageClass defines the  custom storageclass which should be set as default
 // This applies only to storageClasses managed by metal-stack.
 // If set to nil, our default storageClass (e.g. csi-lvm) is set as default
+// The admission defaults csi-lvm unless it is disabled. Without csi-lvm, the duros storage class of the partition is
+// set as default when the control plane is reconciled, as the duros storage config is part of the seed configuration.
 type CustomDefaultStorageClass struct {
 	// ClassName name of the storageclass to be set as default
 	// If you want to have your own SC be set as default, set classname to ""
@@ -75,6 +142,19 @@ type CloudControllerManagerConfig struct {
 	DefaultExternalNetwork *string
 }
 
+const (
+	// DefaultNetworkPolicyAllowToDNS is the default network policy which allows egress traffic to the dns servers.
+	DefaultNetworkPolicyAllowToDNS = "allow-to-dns"
+	// DefaultNetworkPolicyAllowToNTP is the default network policy which allows egress traffic to the ntp servers.
+	DefaultNetworkPolicyAllowToNTP = "allow-to-ntp"
+	// DefaultNetworkPolicyAllowToVPN is the default network policy which allows egress traffic to the vpn of the control plane.
+	DefaultNetworkPolicyAllowToVPN = "allow-to-vpn"
+	// DefaultNetworkPolicyAllowToHTTP is the default network policy which allows egress http traffic in baseline clusters.
+	DefaultNetworkPolicyAllowToHTTP = "allow-to-http"
+	// DefaultNetworkPolicyAllowToHTTPS is the default network policy which allows egress https traffic in baseline clusters.
+	DefaultNetworkPolicyAllowToHTTPS = "allow-to-https"
+)
+
 type (
 	// NetworkAccessType defines how a cluster is capable of accessing external networks
 	NetworkAccessType string
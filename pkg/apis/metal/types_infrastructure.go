@@ -46,8 +46,20 @@ type EgressRule struct {
 type InfrastructureStatus struct {
 	metav1.TypeMeta
 	Firewall FirewallStatus
+	// StaticIPs contains the static ips which were reserved for the cluster.
+	StaticIPs []StaticIPStatus
 }
 
 type FirewallStatus struct {
 	MachineID string
 }
+
+// StaticIPStatus contains information about a static ip that was reserved for the cluster.
+type StaticIPStatus struct {
+	// Name is the name of the reservation.
+	Name string
+	// Network is the id of the network from which the ip was allocated.
+	Network string
+	// IP is the allocated ip address.
+	IP string
+}
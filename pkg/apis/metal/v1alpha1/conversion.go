@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+
+	"k8s.io/apimachinery/pkg/conversion"
+)
+
+// Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation converts the deprecated string representation of dns and
+// ntp servers and the structured endpoints into network servers. Setting both is refused by the cloud profile validation.
+func Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(in *NetworkIsolation, out *metal.NetworkIsolation, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(in, out, s); err != nil {
+		return err
+	}
+
+	dnsServers, err := convertNetworkServers(in.DNSServers, in.DNSEndpoints, s)
+	if err != nil {
+		return err
+	}
+	out.DNSServers = dnsServers
+
+	ntpServers, err := convertNetworkServers(in.NTPServers, in.NTPEndpoints, s)
+	if err != nil {
+		return err
+	}
+	out.NTPServers = ntpServers
+
+	return nil
+}
+
+// Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation converts the network servers into the structured endpoints.
+func Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(in *metal.NetworkIsolation, out *NetworkIsolation, s conversion.Scope) error {
+	if err := autoConvert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(in, out, s); err != nil {
+		return err
+	}
+
+	out.DNSServers = nil
+	out.DNSEndpoints = nil
+	for i := range in.DNSServers {
+		var endpoint NetworkServer
+		if err := Convert_metal_NetworkServer_To_v1alpha1_NetworkServer(&in.DNSServers[i], &endpoint, s); err != nil {
+			return err
+		}
+		out.DNSEndpoints = append(out.DNSEndpoints, endpoint)
+	}
+
+	out.NTPServers = nil
+	out.NTPEndpoints = nil
+	for i := range in.NTPServers {
+		var endpoint NetworkServer
+		if err := Convert_metal_NetworkServer_To_v1alpha1_NetworkServer(&in.NTPServers[i], &endpoint, s); err != nil {
+			return err
+		}
+		out.NTPEndpoints = append(out.NTPEndpoints, endpoint)
+	}
+
+	return nil
+}
+
+func convertNetworkServers(servers []string, endpoints []NetworkServer, s conversion.Scope) ([]metal.NetworkServer, error) {
+	var result []metal.NetworkServer
+
+	for _, server := range servers {
+		result = append(result, metal.NetworkServer{IP: server})
+	}
+
+	for i := range endpoints {
+		var server metal.NetworkServer
+		if err := Convert_v1alpha1_NetworkServer_To_metal_NetworkServer(&endpoints[i], &server, s); err != nil {
+			return nil, err
+		}
+		result = append(result, server)
+	}
+
+	return result, nil
+}
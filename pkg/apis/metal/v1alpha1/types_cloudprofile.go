@@ -23,10 +23,29 @@ type MetalControlPlane struct {
 	Partitions map[string]Partition `json:"partitions"`
 	// FirewallImages is a list of available firewall images in this control plane. When empty, allows all values.
 	FirewallImages []string `json:"firewallImages,omitempty"`
+	// FirewallImageClassifications maps firewall images to their classification. Preview and deprecated images are not
+	// chosen as the default firewall image of new shoots. Images which are not classified are considered supported.
+	// +optional
+	FirewallImageClassifications map[string]VersionClassification `json:"firewallImageClassifications,omitempty"`
 	// FirewallControllerVersions is a list of available firewall controller binary versions
 	FirewallControllerVersions []FirewallControllerVersion `json:"firewallControllerVersions,omitempty"`
 	// NftablesExporter is the nftables exporter which will be reconciled by the firewall controller
 	NftablesExporter NftablesExporter `json:"nftablesExporter"`
+	// ValidateNetworks enables the validation of the firewall networks and the additional networks of the worker pools
+	// of a shoot against the metal-api during admission. The admission component requires access to the metal-api for
+	// this purpose. The networks of shoots whose credentials binding references a workload identity are not validated,
+	// as the tokens of workload identities are only issued into the seed. Shoots with a restricted or forbidden network
+	// access type can only use additional networks for their worker pools if they are validated.
+	// +optional
+	ValidateNetworks *bool `json:"validateNetworks,omitempty"`
+	// ValidateCredentials enables the validation of the credentials referenced by secret and credentials bindings
+	// against the metal-api during admission. It also validates that the project of a shoot belongs to its tenant and
+	// is accessible with its credentials. The admission component requires access to the metal-api for this purpose.
+	// The credentials of a binding are validated against the metal control planes of the shoots which use it, so the
+	// credentials of a new binding are only validated on the admission of the first shoot which uses it. The project
+	// of shoots whose credentials binding references a workload identity is not validated.
+	// +optional
+	ValidateCredentials *bool `json:"validateCredentials,omitempty"`
 }
 
 // FirewallControllerVersion describes the version of the firewall controller binary
@@ -75,18 +94,56 @@ type Partition struct {
 
 	// NetworkIsolation if given allows the creation of shoot clusters which have network restrictions activated.
 	NetworkIsolation *NetworkIsolation `json:"networkIsolation,omitempty"`
+
+	// MaxRateLimit is the maximum rate limit in Mbit/s which can be configured for a firewall network in this partition.
+	// +optional
+	MaxRateLimit *uint32 `json:"maxRateLimit,omitempty"`
+
+	// FirewallDefaulting is the policy by which the firewall of shoots in this partition is defaulted.
+	// +optional
+	FirewallDefaulting *FirewallDefaulting `json:"firewallDefaulting,omitempty"`
+
+	// DefaultExternalNetworks are the external networks which are added to the firewall of shoots in this partition
+	// when the shoot does not define any firewall networks.
+	// +optional
+	DefaultExternalNetworks []string `json:"defaultExternalNetworks,omitempty"`
+}
+
+// FirewallDefaulting is the policy by which the firewall of shoots is defaulted.
+type FirewallDefaulting struct {
+	// Size is the default firewall size. If not set, the first firewall type of the partition is used.
+	// +optional
+	Size *string `json:"size,omitempty"`
+	// ImageOS is the preferred operating system of the default firewall image, e.g. firewall-ubuntu. If not set, the
+	// newest image of any operating system is used.
+	// +optional
+	ImageOS *string `json:"imageOS,omitempty"`
 }
 
 // NetworkIsolation defines configuration for restricted or forbidden clusters.
 type NetworkIsolation struct {
 	// AllowedNetworks is a list of networks which are allowed to connect in restricted or forbidden NetworkIsolated clusters.
 	AllowedNetworks AllowedNetworks `json:"allowedNetworks"`
-	// DNSServers
+	// ApprovableNetworks is a list of networks which can be added to the allowed networks of a restricted or forbidden
+	// NetworkIsolated cluster through the additional allowed networks of the control plane config.
+	// +optional
+	ApprovableNetworks *AllowedNetworks `json:"approvableNetworks,omitempty"`
+	// DNSServers is a list of dns server addresses.
+	// Deprecated: Use DNSEndpoints instead.
 	DNSServers []string `json:"dnsServers,omitempty"`
-	// NTPServers
+	// DNSEndpoints are the dns servers which are reachable from restricted or forbidden NetworkIsolated clusters.
+	DNSEndpoints []NetworkServer `json:"dnsEndpoints,omitempty"`
+	// NTPServers is a list of ntp server addresses.
+	// Deprecated: Use NTPEndpoints instead.
 	NTPServers []string `json:"ntpServers,omitempty"`
+	// NTPEndpoints are the ntp servers which are reachable from restricted or forbidden NetworkIsolated clusters.
+	NTPEndpoints []NetworkServer `json:"ntpEndpoints,omitempty"`
 	// The registry which serves the images required to create a shoot.
 	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+	// DefaultNetworkPolicies is the list of default cluster-wide network policies which are deployed into restricted or
+	// forbidden NetworkIsolated clusters. If not set, the defaults of the seed are used.
+	// +optional
+	DefaultNetworkPolicies []string `json:"defaultNetworkPolicies,omitempty"`
 }
 
 // AllowedNetworks is a list of networks which are allowed to connect in restricted or forbidden NetworkIsolated clusters.
@@ -97,6 +154,24 @@ type AllowedNetworks struct {
 	Egress []string `json:"egress,omitempty"`
 }
 
+// NetworkServer describes the endpoint of a network service like dns or ntp.
+type NetworkServer struct {
+	// IP is the ipv4 or ipv6 address of this server
+	IP string `json:"ip"`
+	// Protocol over which the service is reachable, the default protocols of the service are used if unset
+	Protocol NetworkServerProtocol `json:"protocol,omitempty"`
+}
+
+// NetworkServerProtocol is the transport protocol of a network server.
+type NetworkServerProtocol string
+
+const (
+	// NetworkServerProtocolTCP describes a network server reachable over tcp.
+	NetworkServerProtocolTCP = NetworkServerProtocol("TCP")
+	// NetworkServerProtocolUDP describes a network server reachable over udp.
+	NetworkServerProtocolUDP = NetworkServerProtocol("UDP")
+)
+
 type RegistryMirror struct {
 	// Name describes this server
 	Name string `json:"name,omitempty"`
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,11 +24,77 @@ type ControlPlaneConfig struct {
 	// NetworkAccessType defines how the cluster can reach external networks.
 	// +optional
 	NetworkAccessType *NetworkAccessType `json:"networkAccessType,omitempty"`
+
+	// StaticIPReservations contains named static ips which are allocated for the cluster in external networks.
+	// The ips are made available to the cloud-controller-manager for services of type load balancer.
+	// +optional
+	StaticIPReservations []StaticIPReservation `json:"staticIPReservations,omitempty"`
+
+	// AdditionalAllowedNetworks are networks which are allowed to be reached from a restricted or forbidden cluster
+	// in addition to the allowed networks of the partition.
+	// The networks must be contained in the approvable networks of the partition's network isolation.
+	// +optional
+	AdditionalAllowedNetworks *AllowedNetworks `json:"additionalAllowedNetworks,omitempty"`
+
+	// NetworkPolicies are cluster-wide network policies which are deployed into the shoot in addition to the default
+	// policies of the cluster. They allow shipping a shoot with a policy baseline from the beginning.
+	// For clusters with network access type forbidden, the networks must be contained in the allowed networks.
+	// +optional
+	NetworkPolicies []NetworkPolicy `json:"networkPolicies,omitempty"`
+
+	// DisabledDefaultNetworkPolicies is a list of default cluster-wide network policies which are not deployed into the shoot.
+	// If the default policies for dns or ntp are disabled, the servers must be reachable through the network policies
+	// of this config.
+	// +optional
+	DisabledDefaultNetworkPolicies []string `json:"disabledDefaultNetworkPolicies,omitempty"`
+}
+
+// NetworkPolicy declares a cluster-wide network policy which is deployed into the shoot.
+type NetworkPolicy struct {
+	// Name is the name of the policy, it must be unique within the cluster.
+	Name string `json:"name"`
+	// Egress is a list of rules which allow outgoing traffic.
+	// +optional
+	Egress []NetworkPolicyRule `json:"egress,omitempty"`
+	// Ingress is a list of rules which allow incoming traffic.
+	// +optional
+	Ingress []NetworkPolicyRule `json:"ingress,omitempty"`
+}
+
+// NetworkPolicyRule allows traffic to or from the given networks.
+type NetworkPolicyRule struct {
+	// CIDRs are the networks to which (egress) or from which (ingress) traffic is allowed.
+	CIDRs []string `json:"cidrs"`
+	// Ports restricts the traffic to the given ports, if empty all ports are allowed.
+	// +optional
+	Ports []NetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// NetworkPolicyPort describes a port of a network policy rule.
+type NetworkPolicyPort struct {
+	// Protocol is the transport protocol, either TCP or UDP.
+	Protocol corev1.Protocol `json:"protocol"`
+	// Port is the port number.
+	Port int32 `json:"port"`
+}
+
+// StaticIPReservation declares a named static ip that is reserved for the cluster.
+type StaticIPReservation struct {
+	// Name is the name of the reservation, it must be unique within the cluster.
+	Name string `json:"name"`
+	// Network is the id of the external network from which the ip is allocated.
+	Network string `json:"network"`
+	// Ephemeral defines that the ip is released when the cluster is deleted.
+	// By default, the ip is kept in the project and only the cluster tags are removed from it.
+	// +optional
+	Ephemeral bool `json:"ephemeral,omitempty"`
 }
 
 // CustomDefaultStorageClass defines the custom storageclass which should be set as default
 // This applies only to storageClasses managed by metal-stack.
 // If set to nil, our default storageClass (e.g. csi-lvm) is set as default
+// The admission defaults csi-lvm unless it is disabled. Without csi-lvm, the duros storage class of the partition is
+// set as default when the control plane is reconciled, as the duros storage config is part of the seed configuration.
 type CustomDefaultStorageClass struct {
 	// ClassName name of the storageclass to be set as default
 	// If you want to have your own SC be set as default, set classname to ""
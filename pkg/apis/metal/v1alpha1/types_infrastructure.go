@@ -48,8 +48,21 @@ type EgressRule struct {
 type InfrastructureStatus struct {
 	metav1.TypeMeta `json:",inline"`
 	Firewall        FirewallStatus `json:"firewall"`
+	// StaticIPs contains the static ips which were reserved for the cluster.
+	// +optional
+	StaticIPs []StaticIPStatus `json:"staticIPs,omitempty"`
 }
 
 type FirewallStatus struct {
 	MachineID string `json:"machineID"`
 }
+
+// StaticIPStatus contains information about a static ip that was reserved for the cluster.
+type StaticIPStatus struct {
+	// Name is the name of the reservation.
+	Name string `json:"name"`
+	// Network is the id of the network from which the ip was allocated.
+	Network string `json:"network"`
+	// IP is the allocated ip address.
+	IP string `json:"ip"`
+}
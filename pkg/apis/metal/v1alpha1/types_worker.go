@@ -6,6 +6,29 @@ import (
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// WorkerConfig contains configuration settings for the machines of a worker pool.
+type WorkerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// AdditionalNetworks are networks of the metal-api to which the machines of the pool are attached in addition
+	// to the private network of the cluster.
+	// +optional
+	AdditionalNetworks []string `json:"additionalNetworks,omitempty"`
+	// Tags are additional tags in the form of key=value which are added to the machines of the pool.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// PlacementTags are used for spreading the machines of the pool. Machines having the same placement tags are
+	// preferably allocated in different racks of the partition.
+	// +optional
+	PlacementTags []string `json:"placementTags,omitempty"`
+	// ImageID pins the image of the machines of the pool to the given metal-api image, overriding the image
+	// resolved from the machine image of the pool.
+	// +optional
+	ImageID *string `json:"imageID,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // WorkerStatus contains information about created worker resources.
 type WorkerStatus struct {
 	metav1.TypeMeta `json:",inline"`
@@ -16,6 +39,30 @@ type WorkerStatus struct {
 	// resources that are still using this version. Hence, it stores the used versions in the provider status to ensure
 	// reconciliation is possible.
 	MachineImages []MachineImage `json:"machineImages,omitempty"`
+
+	// NetworkAccess contains information about the network access type which is applied to the firewall of the cluster.
+	// +optional
+	NetworkAccess *NetworkAccessStatus `json:"networkAccess,omitempty"`
+}
+
+// NetworkAccessStatus contains information about the network access type which is applied to the firewall of the cluster.
+type NetworkAccessStatus struct {
+	// Type is the network access type which is currently applied to the firewall deployment.
+	Type NetworkAccessType `json:"type"`
+	// Migration contains information about an ongoing migration to a stricter network access type.
+	// +optional
+	Migration *NetworkAccessMigration `json:"migration,omitempty"`
+}
+
+// NetworkAccessMigration describes the progress of a migration to a stricter network access type.
+type NetworkAccessMigration struct {
+	// Target is the network access type to which the cluster is migrated.
+	Target NetworkAccessType `json:"target"`
+	// Message describes why the migration cannot be completed yet.
+	Message string `json:"message,omitempty"`
+	// UncoveredImages are images running in the cluster which are not served by a registry mirror of the partition.
+	// +optional
+	UncoveredImages []string `json:"uncoveredImages,omitempty"`
 }
 
 // MachineImage is a mapping from logical names and versions to specific identifiers.
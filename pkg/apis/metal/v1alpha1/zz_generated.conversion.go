@@ -13,6 +13,7 @@ import (
 	unsafe "unsafe"
 
 	metal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -115,6 +116,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FirewallDefaulting)(nil), (*metal.FirewallDefaulting)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FirewallDefaulting_To_metal_FirewallDefaulting(a.(*FirewallDefaulting), b.(*metal.FirewallDefaulting), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.FirewallDefaulting)(nil), (*FirewallDefaulting)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_FirewallDefaulting_To_v1alpha1_FirewallDefaulting(a.(*metal.FirewallDefaulting), b.(*FirewallDefaulting), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FirewallStatus)(nil), (*metal.FirewallStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_FirewallStatus_To_metal_FirewallStatus(a.(*FirewallStatus), b.(*metal.FirewallStatus), scope)
 	}); err != nil {
@@ -175,13 +186,63 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*NetworkIsolation)(nil), (*metal.NetworkIsolation)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(a.(*NetworkIsolation), b.(*metal.NetworkIsolation), scope)
+	if err := s.AddGeneratedConversionFunc((*NetworkAccessMigration)(nil), (*metal.NetworkAccessMigration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkAccessMigration_To_metal_NetworkAccessMigration(a.(*NetworkAccessMigration), b.(*metal.NetworkAccessMigration), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*metal.NetworkIsolation)(nil), (*NetworkIsolation)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(a.(*metal.NetworkIsolation), b.(*NetworkIsolation), scope)
+	if err := s.AddGeneratedConversionFunc((*metal.NetworkAccessMigration)(nil), (*NetworkAccessMigration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkAccessMigration_To_v1alpha1_NetworkAccessMigration(a.(*metal.NetworkAccessMigration), b.(*NetworkAccessMigration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkAccessStatus)(nil), (*metal.NetworkAccessStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkAccessStatus_To_metal_NetworkAccessStatus(a.(*NetworkAccessStatus), b.(*metal.NetworkAccessStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.NetworkAccessStatus)(nil), (*NetworkAccessStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkAccessStatus_To_v1alpha1_NetworkAccessStatus(a.(*metal.NetworkAccessStatus), b.(*NetworkAccessStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicy)(nil), (*metal.NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkPolicy_To_metal_NetworkPolicy(a.(*NetworkPolicy), b.(*metal.NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.NetworkPolicy)(nil), (*NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkPolicy_To_v1alpha1_NetworkPolicy(a.(*metal.NetworkPolicy), b.(*NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyPort)(nil), (*metal.NetworkPolicyPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkPolicyPort_To_metal_NetworkPolicyPort(a.(*NetworkPolicyPort), b.(*metal.NetworkPolicyPort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.NetworkPolicyPort)(nil), (*NetworkPolicyPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkPolicyPort_To_v1alpha1_NetworkPolicyPort(a.(*metal.NetworkPolicyPort), b.(*NetworkPolicyPort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyRule)(nil), (*metal.NetworkPolicyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkPolicyRule_To_metal_NetworkPolicyRule(a.(*NetworkPolicyRule), b.(*metal.NetworkPolicyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.NetworkPolicyRule)(nil), (*NetworkPolicyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkPolicyRule_To_v1alpha1_NetworkPolicyRule(a.(*metal.NetworkPolicyRule), b.(*NetworkPolicyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkServer)(nil), (*metal.NetworkServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkServer_To_metal_NetworkServer(a.(*NetworkServer), b.(*metal.NetworkServer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.NetworkServer)(nil), (*NetworkServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkServer_To_v1alpha1_NetworkServer(a.(*metal.NetworkServer), b.(*NetworkServer), scope)
 	}); err != nil {
 		return err
 	}
@@ -225,6 +286,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StaticIPReservation)(nil), (*metal.StaticIPReservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StaticIPReservation_To_metal_StaticIPReservation(a.(*StaticIPReservation), b.(*metal.StaticIPReservation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.StaticIPReservation)(nil), (*StaticIPReservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_StaticIPReservation_To_v1alpha1_StaticIPReservation(a.(*metal.StaticIPReservation), b.(*StaticIPReservation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaticIPStatus)(nil), (*metal.StaticIPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StaticIPStatus_To_metal_StaticIPStatus(a.(*StaticIPStatus), b.(*metal.StaticIPStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.StaticIPStatus)(nil), (*StaticIPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_StaticIPStatus_To_v1alpha1_StaticIPStatus(a.(*metal.StaticIPStatus), b.(*StaticIPStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WorkerConfig)(nil), (*metal.WorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(a.(*WorkerConfig), b.(*metal.WorkerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.WorkerConfig)(nil), (*WorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(a.(*metal.WorkerConfig), b.(*WorkerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*WorkerStatus)(nil), (*metal.WorkerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_WorkerStatus_To_metal_WorkerStatus(a.(*WorkerStatus), b.(*metal.WorkerStatus), scope)
 	}); err != nil {
@@ -235,6 +326,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*metal.NetworkIsolation)(nil), (*NetworkIsolation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(a.(*metal.NetworkIsolation), b.(*NetworkIsolation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*NetworkIsolation)(nil), (*metal.NetworkIsolation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(a.(*NetworkIsolation), b.(*metal.NetworkIsolation), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -283,7 +384,19 @@ func Convert_metal_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManag
 }
 
 func autoConvert_v1alpha1_CloudProfileConfig_To_metal_CloudProfileConfig(in *CloudProfileConfig, out *metal.CloudProfileConfig, s conversion.Scope) error {
-	out.MetalControlPlanes = *(*map[string]metal.MetalControlPlane)(unsafe.Pointer(&in.MetalControlPlanes))
+	if in.MetalControlPlanes != nil {
+		in, out := &in.MetalControlPlanes, &out.MetalControlPlanes
+		*out = make(map[string]metal.MetalControlPlane, len(*in))
+		for key, val := range *in {
+			newVal := new(metal.MetalControlPlane)
+			if err := Convert_v1alpha1_MetalControlPlane_To_metal_MetalControlPlane(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.MetalControlPlanes = nil
+	}
 	return nil
 }
 
@@ -293,7 +406,19 @@ func Convert_v1alpha1_CloudProfileConfig_To_metal_CloudProfileConfig(in *CloudPr
 }
 
 func autoConvert_metal_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *metal.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
-	out.MetalControlPlanes = *(*map[string]MetalControlPlane)(unsafe.Pointer(&in.MetalControlPlanes))
+	if in.MetalControlPlanes != nil {
+		in, out := &in.MetalControlPlanes, &out.MetalControlPlanes
+		*out = make(map[string]MetalControlPlane, len(*in))
+		for key, val := range *in {
+			newVal := new(MetalControlPlane)
+			if err := Convert_metal_MetalControlPlane_To_v1alpha1_MetalControlPlane(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.MetalControlPlanes = nil
+	}
 	return nil
 }
 
@@ -309,6 +434,10 @@ func autoConvert_v1alpha1_ControlPlaneConfig_To_metal_ControlPlaneConfig(in *Con
 	}
 	out.CustomDefaultStorageClass = (*metal.CustomDefaultStorageClass)(unsafe.Pointer(in.CustomDefaultStorageClass))
 	out.NetworkAccessType = (*metal.NetworkAccessType)(unsafe.Pointer(in.NetworkAccessType))
+	out.StaticIPReservations = *(*[]metal.StaticIPReservation)(unsafe.Pointer(&in.StaticIPReservations))
+	out.AdditionalAllowedNetworks = (*metal.AllowedNetworks)(unsafe.Pointer(in.AdditionalAllowedNetworks))
+	out.NetworkPolicies = *(*[]metal.NetworkPolicy)(unsafe.Pointer(&in.NetworkPolicies))
+	out.DisabledDefaultNetworkPolicies = *(*[]string)(unsafe.Pointer(&in.DisabledDefaultNetworkPolicies))
 	return nil
 }
 
@@ -324,6 +453,10 @@ func autoConvert_metal_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *met
 	}
 	out.CustomDefaultStorageClass = (*CustomDefaultStorageClass)(unsafe.Pointer(in.CustomDefaultStorageClass))
 	out.NetworkAccessType = (*NetworkAccessType)(unsafe.Pointer(in.NetworkAccessType))
+	out.StaticIPReservations = *(*[]StaticIPReservation)(unsafe.Pointer(&in.StaticIPReservations))
+	out.AdditionalAllowedNetworks = (*AllowedNetworks)(unsafe.Pointer(in.AdditionalAllowedNetworks))
+	out.NetworkPolicies = *(*[]NetworkPolicy)(unsafe.Pointer(&in.NetworkPolicies))
+	out.DisabledDefaultNetworkPolicies = *(*[]string)(unsafe.Pointer(&in.DisabledDefaultNetworkPolicies))
 	return nil
 }
 
@@ -464,6 +597,28 @@ func Convert_metal_FirewallControllerVersion_To_v1alpha1_FirewallControllerVersi
 	return autoConvert_metal_FirewallControllerVersion_To_v1alpha1_FirewallControllerVersion(in, out, s)
 }
 
+func autoConvert_v1alpha1_FirewallDefaulting_To_metal_FirewallDefaulting(in *FirewallDefaulting, out *metal.FirewallDefaulting, s conversion.Scope) error {
+	out.Size = (*string)(unsafe.Pointer(in.Size))
+	out.ImageOS = (*string)(unsafe.Pointer(in.ImageOS))
+	return nil
+}
+
+// Convert_v1alpha1_FirewallDefaulting_To_metal_FirewallDefaulting is an autogenerated conversion function.
+func Convert_v1alpha1_FirewallDefaulting_To_metal_FirewallDefaulting(in *FirewallDefaulting, out *metal.FirewallDefaulting, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FirewallDefaulting_To_metal_FirewallDefaulting(in, out, s)
+}
+
+func autoConvert_metal_FirewallDefaulting_To_v1alpha1_FirewallDefaulting(in *metal.FirewallDefaulting, out *FirewallDefaulting, s conversion.Scope) error {
+	out.Size = (*string)(unsafe.Pointer(in.Size))
+	out.ImageOS = (*string)(unsafe.Pointer(in.ImageOS))
+	return nil
+}
+
+// Convert_metal_FirewallDefaulting_To_v1alpha1_FirewallDefaulting is an autogenerated conversion function.
+func Convert_metal_FirewallDefaulting_To_v1alpha1_FirewallDefaulting(in *metal.FirewallDefaulting, out *FirewallDefaulting, s conversion.Scope) error {
+	return autoConvert_metal_FirewallDefaulting_To_v1alpha1_FirewallDefaulting(in, out, s)
+}
+
 func autoConvert_v1alpha1_FirewallStatus_To_metal_FirewallStatus(in *FirewallStatus, out *metal.FirewallStatus, s conversion.Scope) error {
 	out.MachineID = in.MachineID
 	return nil
@@ -485,7 +640,15 @@ func Convert_metal_FirewallStatus_To_v1alpha1_FirewallStatus(in *metal.FirewallS
 }
 
 func autoConvert_v1alpha1_ImageProviderConfig_To_metal_ImageProviderConfig(in *ImageProviderConfig, out *metal.ImageProviderConfig, s conversion.Scope) error {
-	out.NetworkIsolation = (*metal.NetworkIsolation)(unsafe.Pointer(in.NetworkIsolation))
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(metal.NetworkIsolation)
+		if err := Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkIsolation = nil
+	}
 	return nil
 }
 
@@ -495,7 +658,15 @@ func Convert_v1alpha1_ImageProviderConfig_To_metal_ImageProviderConfig(in *Image
 }
 
 func autoConvert_metal_ImageProviderConfig_To_v1alpha1_ImageProviderConfig(in *metal.ImageProviderConfig, out *ImageProviderConfig, s conversion.Scope) error {
-	out.NetworkIsolation = (*NetworkIsolation)(unsafe.Pointer(in.NetworkIsolation))
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NetworkIsolation)
+		if err := Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkIsolation = nil
+	}
 	return nil
 }
 
@@ -536,6 +707,7 @@ func autoConvert_v1alpha1_InfrastructureStatus_To_metal_InfrastructureStatus(in
 	if err := Convert_v1alpha1_FirewallStatus_To_metal_FirewallStatus(&in.Firewall, &out.Firewall, s); err != nil {
 		return err
 	}
+	out.StaticIPs = *(*[]metal.StaticIPStatus)(unsafe.Pointer(&in.StaticIPs))
 	return nil
 }
 
@@ -548,6 +720,7 @@ func autoConvert_metal_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in
 	if err := Convert_metal_FirewallStatus_To_v1alpha1_FirewallStatus(&in.Firewall, &out.Firewall, s); err != nil {
 		return err
 	}
+	out.StaticIPs = *(*[]StaticIPStatus)(unsafe.Pointer(&in.StaticIPs))
 	return nil
 }
 
@@ -582,12 +755,27 @@ func Convert_metal_MachineImage_To_v1alpha1_MachineImage(in *metal.MachineImage,
 
 func autoConvert_v1alpha1_MetalControlPlane_To_metal_MetalControlPlane(in *MetalControlPlane, out *metal.MetalControlPlane, s conversion.Scope) error {
 	out.Endpoint = in.Endpoint
-	out.Partitions = *(*map[string]metal.Partition)(unsafe.Pointer(&in.Partitions))
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make(map[string]metal.Partition, len(*in))
+		for key, val := range *in {
+			newVal := new(metal.Partition)
+			if err := Convert_v1alpha1_Partition_To_metal_Partition(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Partitions = nil
+	}
 	out.FirewallImages = *(*[]string)(unsafe.Pointer(&in.FirewallImages))
+	out.FirewallImageClassifications = *(*map[string]metal.VersionClassification)(unsafe.Pointer(&in.FirewallImageClassifications))
 	out.FirewallControllerVersions = *(*[]metal.FirewallControllerVersion)(unsafe.Pointer(&in.FirewallControllerVersions))
 	if err := Convert_v1alpha1_NftablesExporter_To_metal_NftablesExporter(&in.NftablesExporter, &out.NftablesExporter, s); err != nil {
 		return err
 	}
+	out.ValidateNetworks = (*bool)(unsafe.Pointer(in.ValidateNetworks))
+	out.ValidateCredentials = (*bool)(unsafe.Pointer(in.ValidateCredentials))
 	return nil
 }
 
@@ -598,12 +786,27 @@ func Convert_v1alpha1_MetalControlPlane_To_metal_MetalControlPlane(in *MetalCont
 
 func autoConvert_metal_MetalControlPlane_To_v1alpha1_MetalControlPlane(in *metal.MetalControlPlane, out *MetalControlPlane, s conversion.Scope) error {
 	out.Endpoint = in.Endpoint
-	out.Partitions = *(*map[string]Partition)(unsafe.Pointer(&in.Partitions))
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make(map[string]Partition, len(*in))
+		for key, val := range *in {
+			newVal := new(Partition)
+			if err := Convert_metal_Partition_To_v1alpha1_Partition(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Partitions = nil
+	}
 	out.FirewallImages = *(*[]string)(unsafe.Pointer(&in.FirewallImages))
+	out.FirewallImageClassifications = *(*map[string]VersionClassification)(unsafe.Pointer(&in.FirewallImageClassifications))
 	out.FirewallControllerVersions = *(*[]FirewallControllerVersion)(unsafe.Pointer(&in.FirewallControllerVersions))
 	if err := Convert_metal_NftablesExporter_To_v1alpha1_NftablesExporter(&in.NftablesExporter, &out.NftablesExporter, s); err != nil {
 		return err
 	}
+	out.ValidateNetworks = (*bool)(unsafe.Pointer(in.ValidateNetworks))
+	out.ValidateCredentials = (*bool)(unsafe.Pointer(in.ValidateCredentials))
 	return nil
 }
 
@@ -612,34 +815,166 @@ func Convert_metal_MetalControlPlane_To_v1alpha1_MetalControlPlane(in *metal.Met
 	return autoConvert_metal_MetalControlPlane_To_v1alpha1_MetalControlPlane(in, out, s)
 }
 
+func autoConvert_v1alpha1_NetworkAccessMigration_To_metal_NetworkAccessMigration(in *NetworkAccessMigration, out *metal.NetworkAccessMigration, s conversion.Scope) error {
+	out.Target = metal.NetworkAccessType(in.Target)
+	out.Message = in.Message
+	out.UncoveredImages = *(*[]string)(unsafe.Pointer(&in.UncoveredImages))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkAccessMigration_To_metal_NetworkAccessMigration is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkAccessMigration_To_metal_NetworkAccessMigration(in *NetworkAccessMigration, out *metal.NetworkAccessMigration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkAccessMigration_To_metal_NetworkAccessMigration(in, out, s)
+}
+
+func autoConvert_metal_NetworkAccessMigration_To_v1alpha1_NetworkAccessMigration(in *metal.NetworkAccessMigration, out *NetworkAccessMigration, s conversion.Scope) error {
+	out.Target = NetworkAccessType(in.Target)
+	out.Message = in.Message
+	out.UncoveredImages = *(*[]string)(unsafe.Pointer(&in.UncoveredImages))
+	return nil
+}
+
+// Convert_metal_NetworkAccessMigration_To_v1alpha1_NetworkAccessMigration is an autogenerated conversion function.
+func Convert_metal_NetworkAccessMigration_To_v1alpha1_NetworkAccessMigration(in *metal.NetworkAccessMigration, out *NetworkAccessMigration, s conversion.Scope) error {
+	return autoConvert_metal_NetworkAccessMigration_To_v1alpha1_NetworkAccessMigration(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkAccessStatus_To_metal_NetworkAccessStatus(in *NetworkAccessStatus, out *metal.NetworkAccessStatus, s conversion.Scope) error {
+	out.Type = metal.NetworkAccessType(in.Type)
+	out.Migration = (*metal.NetworkAccessMigration)(unsafe.Pointer(in.Migration))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkAccessStatus_To_metal_NetworkAccessStatus is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkAccessStatus_To_metal_NetworkAccessStatus(in *NetworkAccessStatus, out *metal.NetworkAccessStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkAccessStatus_To_metal_NetworkAccessStatus(in, out, s)
+}
+
+func autoConvert_metal_NetworkAccessStatus_To_v1alpha1_NetworkAccessStatus(in *metal.NetworkAccessStatus, out *NetworkAccessStatus, s conversion.Scope) error {
+	out.Type = NetworkAccessType(in.Type)
+	out.Migration = (*NetworkAccessMigration)(unsafe.Pointer(in.Migration))
+	return nil
+}
+
+// Convert_metal_NetworkAccessStatus_To_v1alpha1_NetworkAccessStatus is an autogenerated conversion function.
+func Convert_metal_NetworkAccessStatus_To_v1alpha1_NetworkAccessStatus(in *metal.NetworkAccessStatus, out *NetworkAccessStatus, s conversion.Scope) error {
+	return autoConvert_metal_NetworkAccessStatus_To_v1alpha1_NetworkAccessStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(in *NetworkIsolation, out *metal.NetworkIsolation, s conversion.Scope) error {
 	if err := Convert_v1alpha1_AllowedNetworks_To_metal_AllowedNetworks(&in.AllowedNetworks, &out.AllowedNetworks, s); err != nil {
 		return err
 	}
-	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
-	out.NTPServers = *(*[]string)(unsafe.Pointer(&in.NTPServers))
+	out.ApprovableNetworks = (*metal.AllowedNetworks)(unsafe.Pointer(in.ApprovableNetworks))
+	// WARNING: in.DNSServers requires manual conversion: inconvertible types ([]string vs []github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal.NetworkServer)
+	// WARNING: in.DNSEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.NTPServers requires manual conversion: inconvertible types ([]string vs []github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal.NetworkServer)
+	// WARNING: in.NTPEndpoints requires manual conversion: does not exist in peer-type
 	out.RegistryMirrors = *(*[]metal.RegistryMirror)(unsafe.Pointer(&in.RegistryMirrors))
+	out.DefaultNetworkPolicies = *(*[]string)(unsafe.Pointer(&in.DefaultNetworkPolicies))
 	return nil
 }
 
-// Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation is an autogenerated conversion function.
-func Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(in *NetworkIsolation, out *metal.NetworkIsolation, s conversion.Scope) error {
-	return autoConvert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(in, out, s)
-}
-
 func autoConvert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(in *metal.NetworkIsolation, out *NetworkIsolation, s conversion.Scope) error {
 	if err := Convert_metal_AllowedNetworks_To_v1alpha1_AllowedNetworks(&in.AllowedNetworks, &out.AllowedNetworks, s); err != nil {
 		return err
 	}
-	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
-	out.NTPServers = *(*[]string)(unsafe.Pointer(&in.NTPServers))
+	out.ApprovableNetworks = (*AllowedNetworks)(unsafe.Pointer(in.ApprovableNetworks))
+	// WARNING: in.DNSServers requires manual conversion: inconvertible types ([]github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal.NetworkServer vs []string)
+	// WARNING: in.NTPServers requires manual conversion: inconvertible types ([]github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal.NetworkServer vs []string)
 	out.RegistryMirrors = *(*[]RegistryMirror)(unsafe.Pointer(&in.RegistryMirrors))
+	out.DefaultNetworkPolicies = *(*[]string)(unsafe.Pointer(&in.DefaultNetworkPolicies))
+	return nil
+}
+
+func autoConvert_v1alpha1_NetworkPolicy_To_metal_NetworkPolicy(in *NetworkPolicy, out *metal.NetworkPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Egress = *(*[]metal.NetworkPolicyRule)(unsafe.Pointer(&in.Egress))
+	out.Ingress = *(*[]metal.NetworkPolicyRule)(unsafe.Pointer(&in.Ingress))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkPolicy_To_metal_NetworkPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkPolicy_To_metal_NetworkPolicy(in *NetworkPolicy, out *metal.NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkPolicy_To_metal_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_metal_NetworkPolicy_To_v1alpha1_NetworkPolicy(in *metal.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Egress = *(*[]NetworkPolicyRule)(unsafe.Pointer(&in.Egress))
+	out.Ingress = *(*[]NetworkPolicyRule)(unsafe.Pointer(&in.Ingress))
+	return nil
+}
+
+// Convert_metal_NetworkPolicy_To_v1alpha1_NetworkPolicy is an autogenerated conversion function.
+func Convert_metal_NetworkPolicy_To_v1alpha1_NetworkPolicy(in *metal.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_metal_NetworkPolicy_To_v1alpha1_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkPolicyPort_To_metal_NetworkPolicyPort(in *NetworkPolicyPort, out *metal.NetworkPolicyPort, s conversion.Scope) error {
+	out.Protocol = corev1.Protocol(in.Protocol)
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1alpha1_NetworkPolicyPort_To_metal_NetworkPolicyPort is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkPolicyPort_To_metal_NetworkPolicyPort(in *NetworkPolicyPort, out *metal.NetworkPolicyPort, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkPolicyPort_To_metal_NetworkPolicyPort(in, out, s)
+}
+
+func autoConvert_metal_NetworkPolicyPort_To_v1alpha1_NetworkPolicyPort(in *metal.NetworkPolicyPort, out *NetworkPolicyPort, s conversion.Scope) error {
+	out.Protocol = corev1.Protocol(in.Protocol)
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_metal_NetworkPolicyPort_To_v1alpha1_NetworkPolicyPort is an autogenerated conversion function.
+func Convert_metal_NetworkPolicyPort_To_v1alpha1_NetworkPolicyPort(in *metal.NetworkPolicyPort, out *NetworkPolicyPort, s conversion.Scope) error {
+	return autoConvert_metal_NetworkPolicyPort_To_v1alpha1_NetworkPolicyPort(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkPolicyRule_To_metal_NetworkPolicyRule(in *NetworkPolicyRule, out *metal.NetworkPolicyRule, s conversion.Scope) error {
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.Ports = *(*[]metal.NetworkPolicyPort)(unsafe.Pointer(&in.Ports))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkPolicyRule_To_metal_NetworkPolicyRule is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkPolicyRule_To_metal_NetworkPolicyRule(in *NetworkPolicyRule, out *metal.NetworkPolicyRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkPolicyRule_To_metal_NetworkPolicyRule(in, out, s)
+}
+
+func autoConvert_metal_NetworkPolicyRule_To_v1alpha1_NetworkPolicyRule(in *metal.NetworkPolicyRule, out *NetworkPolicyRule, s conversion.Scope) error {
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.Ports = *(*[]NetworkPolicyPort)(unsafe.Pointer(&in.Ports))
+	return nil
+}
+
+// Convert_metal_NetworkPolicyRule_To_v1alpha1_NetworkPolicyRule is an autogenerated conversion function.
+func Convert_metal_NetworkPolicyRule_To_v1alpha1_NetworkPolicyRule(in *metal.NetworkPolicyRule, out *NetworkPolicyRule, s conversion.Scope) error {
+	return autoConvert_metal_NetworkPolicyRule_To_v1alpha1_NetworkPolicyRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkServer_To_metal_NetworkServer(in *NetworkServer, out *metal.NetworkServer, s conversion.Scope) error {
+	out.IP = in.IP
+	out.Protocol = metal.NetworkServerProtocol(in.Protocol)
+	return nil
+}
+
+// Convert_v1alpha1_NetworkServer_To_metal_NetworkServer is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkServer_To_metal_NetworkServer(in *NetworkServer, out *metal.NetworkServer, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkServer_To_metal_NetworkServer(in, out, s)
+}
+
+func autoConvert_metal_NetworkServer_To_v1alpha1_NetworkServer(in *metal.NetworkServer, out *NetworkServer, s conversion.Scope) error {
+	out.IP = in.IP
+	out.Protocol = NetworkServerProtocol(in.Protocol)
 	return nil
 }
 
-// Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation is an autogenerated conversion function.
-func Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(in *metal.NetworkIsolation, out *NetworkIsolation, s conversion.Scope) error {
-	return autoConvert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(in, out, s)
+// Convert_metal_NetworkServer_To_v1alpha1_NetworkServer is an autogenerated conversion function.
+func Convert_metal_NetworkServer_To_v1alpha1_NetworkServer(in *metal.NetworkServer, out *NetworkServer, s conversion.Scope) error {
+	return autoConvert_metal_NetworkServer_To_v1alpha1_NetworkServer(in, out, s)
 }
 
 func autoConvert_v1alpha1_NftablesExporter_To_metal_NftablesExporter(in *NftablesExporter, out *metal.NftablesExporter, s conversion.Scope) error {
@@ -666,7 +1001,18 @@ func Convert_metal_NftablesExporter_To_v1alpha1_NftablesExporter(in *metal.Nftab
 
 func autoConvert_v1alpha1_Partition_To_metal_Partition(in *Partition, out *metal.Partition, s conversion.Scope) error {
 	out.FirewallTypes = *(*[]string)(unsafe.Pointer(&in.FirewallTypes))
-	out.NetworkIsolation = (*metal.NetworkIsolation)(unsafe.Pointer(in.NetworkIsolation))
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(metal.NetworkIsolation)
+		if err := Convert_v1alpha1_NetworkIsolation_To_metal_NetworkIsolation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkIsolation = nil
+	}
+	out.MaxRateLimit = (*uint32)(unsafe.Pointer(in.MaxRateLimit))
+	out.FirewallDefaulting = (*metal.FirewallDefaulting)(unsafe.Pointer(in.FirewallDefaulting))
+	out.DefaultExternalNetworks = *(*[]string)(unsafe.Pointer(&in.DefaultExternalNetworks))
 	return nil
 }
 
@@ -677,7 +1023,18 @@ func Convert_v1alpha1_Partition_To_metal_Partition(in *Partition, out *metal.Par
 
 func autoConvert_metal_Partition_To_v1alpha1_Partition(in *metal.Partition, out *Partition, s conversion.Scope) error {
 	out.FirewallTypes = *(*[]string)(unsafe.Pointer(&in.FirewallTypes))
-	out.NetworkIsolation = (*NetworkIsolation)(unsafe.Pointer(in.NetworkIsolation))
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NetworkIsolation)
+		if err := Convert_metal_NetworkIsolation_To_v1alpha1_NetworkIsolation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkIsolation = nil
+	}
+	out.MaxRateLimit = (*uint32)(unsafe.Pointer(in.MaxRateLimit))
+	out.FirewallDefaulting = (*FirewallDefaulting)(unsafe.Pointer(in.FirewallDefaulting))
+	out.DefaultExternalNetworks = *(*[]string)(unsafe.Pointer(&in.DefaultExternalNetworks))
 	return nil
 }
 
@@ -736,8 +1093,83 @@ func Convert_metal_RegistryMirror_To_v1alpha1_RegistryMirror(in *metal.RegistryM
 	return autoConvert_metal_RegistryMirror_To_v1alpha1_RegistryMirror(in, out, s)
 }
 
+func autoConvert_v1alpha1_StaticIPReservation_To_metal_StaticIPReservation(in *StaticIPReservation, out *metal.StaticIPReservation, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Network = in.Network
+	out.Ephemeral = in.Ephemeral
+	return nil
+}
+
+// Convert_v1alpha1_StaticIPReservation_To_metal_StaticIPReservation is an autogenerated conversion function.
+func Convert_v1alpha1_StaticIPReservation_To_metal_StaticIPReservation(in *StaticIPReservation, out *metal.StaticIPReservation, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StaticIPReservation_To_metal_StaticIPReservation(in, out, s)
+}
+
+func autoConvert_metal_StaticIPReservation_To_v1alpha1_StaticIPReservation(in *metal.StaticIPReservation, out *StaticIPReservation, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Network = in.Network
+	out.Ephemeral = in.Ephemeral
+	return nil
+}
+
+// Convert_metal_StaticIPReservation_To_v1alpha1_StaticIPReservation is an autogenerated conversion function.
+func Convert_metal_StaticIPReservation_To_v1alpha1_StaticIPReservation(in *metal.StaticIPReservation, out *StaticIPReservation, s conversion.Scope) error {
+	return autoConvert_metal_StaticIPReservation_To_v1alpha1_StaticIPReservation(in, out, s)
+}
+
+func autoConvert_v1alpha1_StaticIPStatus_To_metal_StaticIPStatus(in *StaticIPStatus, out *metal.StaticIPStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Network = in.Network
+	out.IP = in.IP
+	return nil
+}
+
+// Convert_v1alpha1_StaticIPStatus_To_metal_StaticIPStatus is an autogenerated conversion function.
+func Convert_v1alpha1_StaticIPStatus_To_metal_StaticIPStatus(in *StaticIPStatus, out *metal.StaticIPStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StaticIPStatus_To_metal_StaticIPStatus(in, out, s)
+}
+
+func autoConvert_metal_StaticIPStatus_To_v1alpha1_StaticIPStatus(in *metal.StaticIPStatus, out *StaticIPStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Network = in.Network
+	out.IP = in.IP
+	return nil
+}
+
+// Convert_metal_StaticIPStatus_To_v1alpha1_StaticIPStatus is an autogenerated conversion function.
+func Convert_metal_StaticIPStatus_To_v1alpha1_StaticIPStatus(in *metal.StaticIPStatus, out *StaticIPStatus, s conversion.Scope) error {
+	return autoConvert_metal_StaticIPStatus_To_v1alpha1_StaticIPStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(in *WorkerConfig, out *metal.WorkerConfig, s conversion.Scope) error {
+	out.AdditionalNetworks = *(*[]string)(unsafe.Pointer(&in.AdditionalNetworks))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.PlacementTags = *(*[]string)(unsafe.Pointer(&in.PlacementTags))
+	out.ImageID = (*string)(unsafe.Pointer(in.ImageID))
+	return nil
+}
+
+// Convert_v1alpha1_WorkerConfig_To_metal_WorkerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(in *WorkerConfig, out *metal.WorkerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(in, out, s)
+}
+
+func autoConvert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(in *metal.WorkerConfig, out *WorkerConfig, s conversion.Scope) error {
+	out.AdditionalNetworks = *(*[]string)(unsafe.Pointer(&in.AdditionalNetworks))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.PlacementTags = *(*[]string)(unsafe.Pointer(&in.PlacementTags))
+	out.ImageID = (*string)(unsafe.Pointer(in.ImageID))
+	return nil
+}
+
+// Convert_metal_WorkerConfig_To_v1alpha1_WorkerConfig is an autogenerated conversion function.
+func Convert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(in *metal.WorkerConfig, out *WorkerConfig, s conversion.Scope) error {
+	return autoConvert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_WorkerStatus_To_metal_WorkerStatus(in *WorkerStatus, out *metal.WorkerStatus, s conversion.Scope) error {
 	out.MachineImages = *(*[]metal.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	out.NetworkAccess = (*metal.NetworkAccessStatus)(unsafe.Pointer(in.NetworkAccess))
 	return nil
 }
 
@@ -748,6 +1180,7 @@ func Convert_v1alpha1_WorkerStatus_To_metal_WorkerStatus(in *WorkerStatus, out *
 
 func autoConvert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in *metal.WorkerStatus, out *WorkerStatus, s conversion.Scope) error {
 	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	out.NetworkAccess = (*NetworkAccessStatus)(unsafe.Pointer(in.NetworkAccess))
 	return nil
 }
 
@@ -4,15 +4,21 @@ import (
 	"fmt"
 	"net/netip"
 	"net/url"
+	"slices"
 
 	"github.com/gardener/gardener/pkg/apis/core"
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
-var supportedVersionClassifications = sets.NewString(string(apismetal.ClassificationPreview), string(apismetal.ClassificationSupported), string(apismetal.ClassificationDeprecated))
+var (
+	supportedVersionClassifications = sets.NewString(string(apismetal.ClassificationPreview), string(apismetal.ClassificationSupported), string(apismetal.ClassificationDeprecated))
+	supportedNetworkServerProtocols = sets.NewString(string(apismetal.NetworkServerProtocolTCP), string(apismetal.NetworkServerProtocolUDP))
+)
 
 // ValidateCloudProfileConfig validates a CloudProfileConfig object.
 func ValidateCloudProfileConfig(cloudProfileConfig *apismetal.CloudProfileConfig, cloudProfile *core.CloudProfile, providerConfigPath *field.Path) field.ErrorList {
@@ -41,11 +47,45 @@ func ValidateCloudProfileConfig(cloudProfileConfig *apismetal.CloudProfileConfig
 			allErrs = append(allErrs, field.Invalid(mcpField.Child("firewallcontrollerversions"), "version", "contains duplicate entries"))
 		}
 
+		firewallImages := sets.NewString(mcp.FirewallImages...)
+		for image, classification := range mcp.FirewallImageClassifications {
+			classificationField := mcpField.Child("firewallImageClassifications").Key(image)
+			if !supportedVersionClassifications.Has(string(classification)) {
+				allErrs = append(allErrs, field.NotSupported(classificationField, classification, supportedVersionClassifications.List()))
+			}
+			if firewallImages.Len() > 0 && !firewallImages.Has(image) {
+				allErrs = append(allErrs, field.Invalid(classificationField, image, "the firewall image is not in the list of firewall images"))
+			}
+		}
+
 		for partitionName, partition := range mcp.Partitions {
 			if !availableZones.Has(partitionName) {
 				allErrs = append(allErrs, field.Invalid(mcpField, partitionName, fmt.Sprintf("the control plane has a partition that is not a configured zone in any of the cloud profile regions: %v", availableZones.List())))
 			}
 
+			if partition.MaxRateLimit != nil && *partition.MaxRateLimit == 0 {
+				allErrs = append(allErrs, field.Invalid(mcpField.Child(partitionName, "maxRateLimit"), *partition.MaxRateLimit, "max rate limit must be greater than zero"))
+			}
+
+			if defaulting := partition.FirewallDefaulting; defaulting != nil && defaulting.Size != nil {
+				if len(partition.FirewallTypes) > 0 && !slices.Contains(partition.FirewallTypes, *defaulting.Size) {
+					allErrs = append(allErrs, field.NotSupported(mcpField.Child(partitionName, "firewallDefaulting", "size"), *defaulting.Size, partition.FirewallTypes))
+				}
+			}
+
+			defaultExternalNetworksField := mcpField.Child(partitionName, "defaultExternalNetworks")
+			defaultExternalNetworks := sets.NewString()
+			for i, networkID := range partition.DefaultExternalNetworks {
+				if networkID == "" {
+					allErrs = append(allErrs, field.Required(defaultExternalNetworksField.Index(i), "network id must not be empty"))
+					continue
+				}
+				if defaultExternalNetworks.Has(networkID) {
+					allErrs = append(allErrs, field.Duplicate(defaultExternalNetworksField.Index(i), networkID))
+				}
+				defaultExternalNetworks.Insert(networkID)
+			}
+
 			if partition.NetworkIsolation == nil {
 				continue
 			}
@@ -64,42 +104,90 @@ func ValidateCloudProfileConfig(cloudProfileConfig *apismetal.CloudProfileConfig
 			allowedNetworksField := networkIsolationField.Child("allowedNetworks")
 			allErrs = append(allErrs, validateAllowedNetworks(allowedNetworksField, allowedNetworks)...)
 
+			if approvableNetworks := partition.NetworkIsolation.ApprovableNetworks; approvableNetworks != nil {
+				approvableNetworksField := networkIsolationField.Child("approvableNetworks")
+				allErrs = append(allErrs, validateCIDRs(approvableNetworksField.Child("egress"), approvableNetworks.Egress)...)
+				allErrs = append(allErrs, validateCIDRs(approvableNetworksField.Child("ingress"), approvableNetworks.Ingress)...)
+			}
+
 			registryMirrors := partition.NetworkIsolation.RegistryMirrors
 			registryMirrorsField := networkIsolationField.Child("registryMirrors")
 			allErrs = append(allErrs, validateRegistryMirrors(registryMirrors, registryMirrorsField)...)
+
+			defaultNetworkPoliciesField := networkIsolationField.Child("defaultNetworkPolicies")
+			allErrs = append(allErrs, validateDefaultNetworkPolicyNames(partition.NetworkIsolation.DefaultNetworkPolicies, sets.New(helper.AllDefaultNetworkPolicies...), defaultNetworkPoliciesField)...)
 		}
 	}
 
 	return allErrs
 }
 
-func validateDNSServers(dnsServers []string, dnsField *field.Path) field.ErrorList {
+// ValidateCloudProfileConfigNetworkServers validates the dns and ntp servers of a versioned CloudProfileConfig object.
+// The deprecated servers and the endpoints are merged into a single list on conversion, so only one of them may be set.
+func ValidateCloudProfileConfigNetworkServers(cloudProfileConfig *metalv1alpha1.CloudProfileConfig, providerConfigPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	controlPlanesPath := providerConfigPath.Child("metalControlPlanes")
+	for mcpName, mcp := range cloudProfileConfig.MetalControlPlanes {
+		for partitionName, partition := range mcp.Partitions {
+			if partition.NetworkIsolation == nil {
+				continue
+			}
+
+			networkIsolationField := controlPlanesPath.Child(mcpName, partitionName, "networkIsolation")
+
+			if len(partition.NetworkIsolation.DNSServers) > 0 && len(partition.NetworkIsolation.DNSEndpoints) > 0 {
+				allErrs = append(allErrs, field.Forbidden(networkIsolationField.Child("dnsServers"), "dnsServers and dnsEndpoints must not be set both, use dnsEndpoints only"))
+			}
+			if len(partition.NetworkIsolation.NTPServers) > 0 && len(partition.NetworkIsolation.NTPEndpoints) > 0 {
+				allErrs = append(allErrs, field.Forbidden(networkIsolationField.Child("ntpServers"), "ntpServers and ntpEndpoints must not be set both, use ntpEndpoints only"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateDNSServers(dnsServers []apismetal.NetworkServer, dnsField *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 	if len(dnsServers) == 0 {
-		errs = append(errs, field.Invalid(dnsField, dnsServers, "may not be empty"))
+		errs = append(errs, field.Invalid(dnsField, networkServerAddresses(dnsServers), "may not be empty"))
 	}
 	if len(dnsServers) > 3 {
-		errs = append(errs, field.Invalid(dnsField, dnsServers, "only up to 3 dns servers are allowed"))
+		errs = append(errs, field.Invalid(dnsField, networkServerAddresses(dnsServers), "only up to 3 dns servers are allowed"))
 	}
-	for index, ip := range dnsServers {
-		ipField := dnsField.Index(index)
-		if _, err := netip.ParseAddr(ip); err != nil {
-			errs = append(errs, field.Invalid(ipField, ip, "invalid ip address"))
-		}
+	for index, server := range dnsServers {
+		errs = append(errs, validateNetworkServer(server, dnsField.Index(index))...)
+	}
+	// the dns proxy of the firewall forwards the queries of the cluster over udp to the first of these servers
+	if len(dnsServers) > 0 && !slices.ContainsFunc(dnsServers, func(s apismetal.NetworkServer) bool {
+		return s.Protocol == "" || s.Protocol == apismetal.NetworkServerProtocolUDP
+	}) {
+		errs = append(errs, field.Invalid(dnsField, networkServerAddresses(dnsServers), "at least one dns server must be reachable over udp"))
 	}
 	return errs
 }
 
-func validateNTPServers(ntpServers []string, ntpField *field.Path) field.ErrorList {
+func validateNTPServers(ntpServers []apismetal.NetworkServer, ntpField *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 	if len(ntpServers) == 0 {
-		errs = append(errs, field.Invalid(ntpField, ntpServers, "may not be empty"))
+		errs = append(errs, field.Invalid(ntpField, networkServerAddresses(ntpServers), "may not be empty"))
 	}
-	for index, ip := range ntpServers {
-		ipField := ntpField.Index(index)
-		if _, err := netip.ParseAddr(ip); err != nil {
-			errs = append(errs, field.Invalid(ipField, ip, "invalid ip address"))
-		}
+	for index, server := range ntpServers {
+		errs = append(errs, validateNetworkServer(server, ntpField.Index(index))...)
+	}
+	return errs
+}
+
+// validateNetworkServer validates a dns or ntp server. the servers are handed to the worker machines and the os
+// extension as plain addresses, so they are always reached at the default port of the service.
+func validateNetworkServer(server apismetal.NetworkServer, serverField *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if _, err := netip.ParseAddr(server.IP); err != nil {
+		errs = append(errs, field.Invalid(serverField.Child("ip"), server.IP, "invalid ip address"))
+	}
+	if server.Protocol != "" && !supportedNetworkServerProtocols.Has(string(server.Protocol)) {
+		errs = append(errs, field.NotSupported(serverField.Child("protocol"), server.Protocol, supportedNetworkServerProtocols.List()))
 	}
 	return errs
 }
@@ -112,19 +200,20 @@ func validateAllowedNetworks(allowedNetworksField *field.Path, allowedNetworks a
 	if len(egress) == 0 {
 		errs = append(errs, field.Invalid(egressField, egress, "may not be empty"))
 	}
-	for index, cidr := range egress {
-		ipField := egressField.Index(index)
-		if _, err := netip.ParsePrefix(cidr); err != nil {
-			errs = append(errs, field.Invalid(ipField, cidr, "invalid cidr"))
-		}
-	}
+	errs = append(errs, validateCIDRs(egressField, egress)...)
 	ingress := allowedNetworks.Ingress
 	ingressField := allowedNetworksField.Child("ingress")
 	if len(ingress) == 0 {
 		errs = append(errs, field.Invalid(ingressField, ingress, "may not be empty"))
 	}
-	for index, cidr := range ingress {
-		ipField := ingressField.Index(index)
+	errs = append(errs, validateCIDRs(ingressField, ingress)...)
+	return errs
+}
+
+func validateCIDRs(cidrsField *field.Path, cidrs []string) field.ErrorList {
+	errs := field.ErrorList{}
+	for index, cidr := range cidrs {
+		ipField := cidrsField.Index(index)
 		if _, err := netip.ParsePrefix(cidr); err != nil {
 			errs = append(errs, field.Invalid(ipField, cidr, "invalid cidr"))
 		}
@@ -216,19 +305,19 @@ func ValidateImmutableCloudProfileConfig(
 					dnsField,
 					partition.NetworkIsolation.DNSServers,
 					[]string{
-						fmt.Sprintf("%s", partition.NetworkIsolation.DNSServers),
+						fmt.Sprintf("%s", networkServerAddresses(partition.NetworkIsolation.DNSServers)),
 					},
 				))
 				continue
 			}
-			for index, ip := range partition.NetworkIsolation.DNSServers {
-				ipField := networkIsolationField.Child("dnsServers").Index(index)
-				oldIp := oldPartition.NetworkIsolation.DNSServers[index]
-				if ip != oldIp {
+			for index, server := range partition.NetworkIsolation.DNSServers {
+				serverField := networkIsolationField.Child("dnsServers").Index(index)
+				oldServer := oldPartition.NetworkIsolation.DNSServers[index]
+				if server != oldServer {
 					allErrs = append(allErrs, field.NotSupported(
-						ipField,
-						ip,
-						[]string{oldIp},
+						serverField,
+						server.IP,
+						[]string{oldServer.IP},
 					))
 				}
 			}
@@ -237,3 +326,11 @@ func ValidateImmutableCloudProfileConfig(
 
 	return allErrs
 }
+
+func networkServerAddresses(servers []apismetal.NetworkServer) []string {
+	var result []string
+	for _, server := range servers {
+		result = append(result, server.IP)
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package validation_test
 import (
 	"github.com/gardener/gardener/pkg/apis/core"
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
 
 	. "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -100,8 +101,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1/24"},
 									Egress:  []string{"100.0.0.1/24"},
 								},
-								DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-								NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -196,8 +197,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1/24"},
 									Egress:  []string{"100.0.0.1/24"},
 								},
-								DNSServers: []string{"1.1.1.1", "1.0.0.1", "8.8.8.8"},
-								NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}, {IP: "8.8.8.8"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -227,7 +228,7 @@ var _ = Describe("CloudProfileConfig validation", func() {
 					Partitions: map[string]apismetal.Partition{
 						"partition-b": {
 							NetworkIsolation: &apismetal.NetworkIsolation{
-								DNSServers: []string{"1.1.1.1", "1.0.0.1", "8.8.8.8", "8.8.4.4"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}, {IP: "8.8.8.8"}, {IP: "8.8.4.4"}},
 							},
 						},
 					},
@@ -246,6 +247,98 @@ var _ = Describe("CloudProfileConfig validation", func() {
 			))
 		})
 
+		It("should prevent dns servers with invalid protocol", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]apismetal.Partition{
+						"partition-b": {
+							NetworkIsolation: &apismetal.NetworkIsolation{
+								DNSServers: []apismetal.NetworkServer{
+									{IP: "1.1.1.1", Protocol: apismetal.NetworkServerProtocolUDP},
+									{IP: "1.0.0.1", Protocol: "SCTP"},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfig(cloudProfileConfig, cloudProfile, path)
+
+			Expect(errorList).To(ContainElement(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.dnsServers[1].protocol"),
+				})),
+			))
+			Expect(errorList).NotTo(ContainElement(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Field": HavePrefix("test.metalControlPlanes.prod.partition-b.networkIsolation.dnsServers[0]"),
+				})),
+			))
+		})
+
+		It("should prevent dns and ntp servers given in the form ip:port", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]apismetal.Partition{
+						"partition-b": {
+							NetworkIsolation: &apismetal.NetworkIsolation{
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1:5353"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1:1123"}},
+							},
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfig(cloudProfileConfig, cloudProfile, path)
+
+			Expect(errorList).To(ContainElements(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.dnsServers[0].ip"),
+					"BadValue": Equal("1.1.1.1:5353"),
+					"Detail":   Equal("invalid ip address"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.ntpServers[1].ip"),
+					"BadValue": Equal("1.0.0.1:1123"),
+					"Detail":   Equal("invalid ip address"),
+				})),
+			))
+			Expect(errorList).NotTo(ContainElement(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Field": HavePrefix("test.metalControlPlanes.prod.partition-b.networkIsolation.ntpServers[0]"),
+				})),
+			))
+		})
+
+		It("should require a dns server which is reachable over udp", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]apismetal.Partition{
+						"partition-b": {
+							NetworkIsolation: &apismetal.NetworkIsolation{
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1", Protocol: apismetal.NetworkServerProtocolTCP}},
+							},
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfig(cloudProfileConfig, cloudProfile, path)
+
+			Expect(errorList).To(ContainElement(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.dnsServers"),
+					"Detail": Equal("at least one dns server must be reachable over udp"),
+				})),
+			))
+		})
+
 		It("should prevent registry mirrors with empty values", func() {
 			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
 				"prod": {
@@ -313,8 +406,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1"},
 									Egress:  []string{"100.0.0.1/128"},
 								},
-								DNSServers: []string{"1.1.1"},
-								NTPServers: []string{"134.60.1.272"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.272"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -337,13 +430,13 @@ var _ = Describe("CloudProfileConfig validation", func() {
 			Expect(errorList).To(ConsistOf(
 				PointTo(MatchFields(IgnoreExtras, Fields{
 					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.dnsServers[0]"),
+					"Field":    Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.dnsServers[0].ip"),
 					"BadValue": Equal("1.1.1"),
 					"Detail":   Equal("invalid ip address"),
 				})),
 				PointTo(MatchFields(IgnoreExtras, Fields{
 					"Type":     Equal(field.ErrorTypeInvalid),
-					"Field":    Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.ntpServers[0]"),
+					"Field":    Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.ntpServers[0].ip"),
 					"BadValue": Equal("134.60.1.272"),
 					"Detail":   Equal("invalid ip address"),
 				})),
@@ -379,6 +472,101 @@ var _ = Describe("CloudProfileConfig validation", func() {
 				})),
 			))
 		})
+
+		It("should prevent a max rate limit of zero", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]apismetal.Partition{
+						"partition-b": {
+							MaxRateLimit: new(uint32(0)),
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfig(cloudProfileConfig, cloudProfile, path)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("test.metalControlPlanes.prod.partition-b.maxRateLimit"),
+				"Detail": Equal("max rate limit must be greater than zero"),
+			}))))
+		})
+
+		It("should prevent invalid firewall image classifications", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+				"prod": {
+					FirewallImages: []string{"firewall-ubuntu-3.0.20240101"},
+					FirewallImageClassifications: map[string]apismetal.VersionClassification{
+						"firewall-ubuntu-3.0.20240101": "beta",
+						"firewall-ubuntu-2.0.20230101": apismetal.ClassificationDeprecated,
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfig(cloudProfileConfig, cloudProfile, path)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("test.metalControlPlanes.prod.firewallImageClassifications[firewall-ubuntu-3.0.20240101]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("test.metalControlPlanes.prod.firewallImageClassifications[firewall-ubuntu-2.0.20230101]"),
+					"Detail": Equal("the firewall image is not in the list of firewall images"),
+				})),
+			))
+		})
+
+		It("should prevent a default firewall size which is not a firewall type of the partition", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]apismetal.Partition{
+						"partition-b": {
+							FirewallTypes: []string{"n1-medium-x86"},
+							FirewallDefaulting: &apismetal.FirewallDefaulting{
+								Size: new("c1-large-x86"),
+							},
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfig(cloudProfileConfig, cloudProfile, path)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeNotSupported),
+				"Field":    Equal("test.metalControlPlanes.prod.partition-b.firewallDefaulting.size"),
+				"BadValue": Equal("c1-large-x86"),
+			}))))
+		})
+
+		It("should prevent empty and duplicate default external networks", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]apismetal.Partition{
+						"partition-b": {
+							DefaultExternalNetworks: []string{"internet", "", "internet"},
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfig(cloudProfileConfig, cloudProfile, path)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("test.metalControlPlanes.prod.partition-b.defaultExternalNetworks[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeDuplicate),
+					"Field":    Equal("test.metalControlPlanes.prod.partition-b.defaultExternalNetworks[2]"),
+					"BadValue": Equal("internet"),
+				})),
+			))
+		})
 	})
 
 	Describe("#ValidateImmutableCloudProfileConfig", func() {
@@ -410,8 +598,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1/24"},
 									Egress:  []string{"100.0.0.1/24"},
 								},
-								DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-								NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -446,8 +634,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1/24"},
 									Egress:  []string{"100.0.0.1/24"},
 								},
-								DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-								NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -490,8 +678,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1/24"},
 									Egress:  []string{"100.0.0.1/24"},
 								},
-								DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-								NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -518,8 +706,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"192.0.0.1/24"},
 									Egress:  []string{"192.0.0.1/24"},
 								},
-								DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-								NTPServers: []string{"134.0.0.1"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.0.0.1"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry2",
@@ -553,8 +741,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1/24"},
 									Egress:  []string{"100.0.0.1/24"},
 								},
-								DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-								NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+								DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -581,8 +769,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 									Ingress: []string{"10.0.0.1/24"},
 									Egress:  []string{"100.0.0.1/24"},
 								},
-								DNSServers: []string{"8.8.8.8", "8.8.4.4"},
-								NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+								DNSServers: []apismetal.NetworkServer{{IP: "8.8.8.8"}, {IP: "8.8.4.4"}},
+								NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 								RegistryMirrors: []apismetal.RegistryMirror{
 									{
 										Name:     "metal-stack registry",
@@ -619,4 +807,73 @@ var _ = Describe("CloudProfileConfig validation", func() {
 			))
 		})
 	})
+
+	Describe("#ValidateCloudProfileConfigNetworkServers", func() {
+		var (
+			cloudProfileConfig *metalv1alpha1.CloudProfileConfig
+			path               *field.Path
+		)
+
+		BeforeEach(func() {
+			cloudProfileConfig = &metalv1alpha1.CloudProfileConfig{}
+			path = field.NewPath("test")
+		})
+
+		It("should pass either deprecated servers or endpoints", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]metalv1alpha1.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]metalv1alpha1.Partition{
+						"partition-a": {
+							NetworkIsolation: &metalv1alpha1.NetworkIsolation{
+								DNSServers: []string{"1.1.1.1"},
+								NTPServers: []string{"134.60.1.27"},
+							},
+						},
+						"partition-b": {
+							NetworkIsolation: &metalv1alpha1.NetworkIsolation{
+								DNSEndpoints: []metalv1alpha1.NetworkServer{{IP: "1.1.1.1"}},
+								NTPEndpoints: []metalv1alpha1.NetworkServer{{IP: "134.60.1.27"}},
+							},
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfigNetworkServers(cloudProfileConfig, path)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should prevent setting both deprecated servers and endpoints", func() {
+			cloudProfileConfig.MetalControlPlanes = map[string]metalv1alpha1.MetalControlPlane{
+				"prod": {
+					Partitions: map[string]metalv1alpha1.Partition{
+						"partition-b": {
+							NetworkIsolation: &metalv1alpha1.NetworkIsolation{
+								DNSServers:   []string{"1.1.1.1"},
+								DNSEndpoints: []metalv1alpha1.NetworkServer{{IP: "1.0.0.1"}},
+								NTPServers:   []string{"134.60.1.27"},
+								NTPEndpoints: []metalv1alpha1.NetworkServer{{IP: "134.60.111.110"}},
+							},
+						},
+					},
+				},
+			}
+
+			errorList := ValidateCloudProfileConfigNetworkServers(cloudProfileConfig, path)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.dnsServers"),
+					"Detail": Equal("dnsServers and dnsEndpoints must not be set both, use dnsEndpoints only"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("test.metalControlPlanes.prod.partition-b.networkIsolation.ntpServers"),
+					"Detail": Equal("ntpServers and ntpEndpoints must not be set both, use ntpEndpoints only"),
+				})),
+			))
+		})
+	})
 })
@@ -1,17 +1,85 @@
 package validation
 
 import (
+	"fmt"
+
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 
+	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+var (
+	supportedNetworkPolicyProtocols = sets.NewString(string(corev1.ProtocolTCP), string(corev1.ProtocolUDP))
+	// disableableDefaultNetworkPolicies are the default network policies a shoot can opt out of, the vpn policy is
+	// required for the connection to the control plane
+	disableableDefaultNetworkPolicies = sets.New(helper.AllDefaultNetworkPolicies...).Delete(apismetal.DefaultNetworkPolicyAllowToVPN)
+	// defaultNetworkPolicyNames are the names of the cluster-wide network policies deployed by the shoot control plane chart
+	defaultNetworkPolicyNames = sets.NewString(helper.AllDefaultNetworkPolicies...).Insert(
+		"allow-to-additional-networks",
+		"allow-to-apiserver",
+		"allow-to-registry",
+		"allow-to-storage",
+	)
+)
+
 // ValidateControlPlaneConfig validates a ControlPlaneConfig object.
 func ValidateControlPlaneConfig(controlPlaneConfig *apismetal.ControlPlaneConfig, cloudProfile *gardencorev1beta1.CloudProfile, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateFeatureGates(controlPlaneConfig, fldPath)...)
+	allErrs = append(allErrs, validateStaticIPReservations(controlPlaneConfig.StaticIPReservations, fldPath.Child("staticIPReservations"))...)
+	allErrs = append(allErrs, validateNetworkPolicies(controlPlaneConfig.NetworkPolicies, fldPath.Child("networkPolicies"))...)
+	allErrs = append(allErrs, validateDefaultNetworkPolicyNames(controlPlaneConfig.DisabledDefaultNetworkPolicies, disableableDefaultNetworkPolicies, fldPath.Child("disabledDefaultNetworkPolicies"))...)
+
+	return allErrs
+}
+
+// ValidateControlPlaneConfigUpdate validates an update of a ControlPlaneConfig object.
+func ValidateControlPlaneConfigUpdate(oldConfig, newConfig *apismetal.ControlPlaneConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	oldReservations := map[string]apismetal.StaticIPReservation{}
+	for _, r := range oldConfig.StaticIPReservations {
+		oldReservations[r.Name] = r
+	}
+
+	for i, r := range newConfig.StaticIPReservations {
+		old, ok := oldReservations[r.Name]
+		if !ok {
+			continue
+		}
+		// reservations can be added and removed, but an existing reservation cannot be changed as its ip is already allocated
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(r, old, fldPath.Child("staticIPReservations").Index(i))...)
+	}
+
+	allErrs = append(allErrs, validateNetworkAccessTypeUpdate(oldConfig.NetworkAccessType, newConfig.NetworkAccessType, fldPath.Child("networkAccessType"))...)
+
+	return allErrs
+}
+
+// validateNetworkAccessTypeUpdate prevents transitions of the network access type which cannot be migrated safely.
+// a baseline cluster pulls its images from arbitrary registries, so it has to be migrated to restricted first
+// such that the registry mirror coverage of the running images is verified before the firewall forbids other destinations.
+func validateNetworkAccessTypeUpdate(oldType, newType *apismetal.NetworkAccessType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	oldNetworkAccessType := apismetal.NetworkAccessBaseline
+	if oldType != nil {
+		oldNetworkAccessType = *oldType
+	}
+	newNetworkAccessType := apismetal.NetworkAccessBaseline
+	if newType != nil {
+		newNetworkAccessType = *newType
+	}
+
+	if oldNetworkAccessType == apismetal.NetworkAccessBaseline && newNetworkAccessType == apismetal.NetworkAccessForbidden {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("network access type cannot be changed from %s to %s directly, the cluster needs to be migrated to %s first", oldNetworkAccessType, newNetworkAccessType, apismetal.NetworkAccessRestricted)))
+	}
 
 	return allErrs
 }
@@ -21,3 +89,107 @@ func validateFeatureGates(controlPlaneConfig *apismetal.ControlPlaneConfig, fldP
 
 	return allErrs
 }
+
+func validateStaticIPReservations(reservations []apismetal.StaticIPReservation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, r := range reservations {
+		idxPath := fldPath.Index(i)
+
+		if r.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "name of static ip reservation must be set"))
+		} else {
+			for _, msg := range apivalidation.NameIsDNSLabel(r.Name, false) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), r.Name, msg))
+			}
+			if names.Has(r.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), r.Name))
+			}
+			names.Insert(r.Name)
+		}
+
+		if r.Network == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("network"), "network of static ip reservation must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateNetworkPolicies(policies []apismetal.NetworkPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, p := range policies {
+		idxPath := fldPath.Index(i)
+
+		if p.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "name of network policy must be set"))
+		} else {
+			for _, msg := range apivalidation.NameIsDNSSubdomain(p.Name, false) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), p.Name, msg))
+			}
+			if defaultNetworkPolicyNames.Has(p.Name) {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("name"), fmt.Sprintf("name %q is reserved for a default network policy of the cluster", p.Name)))
+			}
+			if names.Has(p.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), p.Name))
+			}
+			names.Insert(p.Name)
+		}
+
+		if len(p.Egress) == 0 && len(p.Ingress) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath, "network policy must contain at least one egress or ingress rule"))
+		}
+
+		for j, rule := range p.Egress {
+			allErrs = append(allErrs, validateNetworkPolicyRule(rule, idxPath.Child("egress").Index(j))...)
+		}
+		for j, rule := range p.Ingress {
+			allErrs = append(allErrs, validateNetworkPolicyRule(rule, idxPath.Child("ingress").Index(j))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateDefaultNetworkPolicyNames(names []string, supported sets.Set[string], fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.New[string]()
+	for i, name := range names {
+		if !supported.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), name, sets.List(supported)))
+			continue
+		}
+		if seen.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), name))
+		}
+		seen.Insert(name)
+	}
+
+	return allErrs
+}
+
+func validateNetworkPolicyRule(rule apismetal.NetworkPolicyRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(rule.CIDRs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cidrs"), "network policy rule must contain at least one cidr"))
+	}
+	allErrs = append(allErrs, validateCIDRs(fldPath.Child("cidrs"), rule.CIDRs)...)
+
+	for i, port := range rule.Ports {
+		portPath := fldPath.Child("ports").Index(i)
+
+		if !supportedNetworkPolicyProtocols.Has(string(port.Protocol)) {
+			allErrs = append(allErrs, field.NotSupported(portPath.Child("protocol"), port.Protocol, supportedNetworkPolicyProtocols.List()))
+		}
+		if port.Port < 1 || port.Port > 65535 {
+			allErrs = append(allErrs, field.Invalid(portPath.Child("port"), port.Port, "must be a valid port"))
+		}
+	}
+
+	return allErrs
+}
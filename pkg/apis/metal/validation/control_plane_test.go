@@ -3,12 +3,14 @@ package validation_test
 import (
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	. "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ControlPlaneconfig validation", func() {
@@ -30,5 +32,221 @@ var _ = Describe("ControlPlaneconfig validation", func() {
 		It("should return no errors for an unchanged config", func() {
 			Expect(ValidateControlPlaneConfig(controlPlaneConfig, cloudProfile, field.NewPath("spec"))).To(BeEmpty())
 		})
+
+		It("should allow valid static ip reservations", func() {
+			controlPlaneConfig.StaticIPReservations = []apismetal.StaticIPReservation{
+				{Name: "ingress", Network: "internet"},
+				{Name: "mail", Network: "internet", Ephemeral: true},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, cloudProfile, field.NewPath("spec"))).To(BeEmpty())
+		})
+
+		It("should forbid invalid static ip reservations", func() {
+			controlPlaneConfig.StaticIPReservations = []apismetal.StaticIPReservation{
+				{Name: "ingress", Network: "internet"},
+				{Name: "ingress", Network: "internet"},
+				{Name: "Not_Valid"},
+			}
+
+			errorList := ValidateControlPlaneConfig(controlPlaneConfig, cloudProfile, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("spec.staticIPReservations[1].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("spec.staticIPReservations[2].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("spec.staticIPReservations[2].network"),
+				})),
+			))
+		})
+
+		It("should allow valid network policies", func() {
+			controlPlaneConfig.NetworkPolicies = []apismetal.NetworkPolicy{
+				{
+					Name: "allow-to-backends",
+					Egress: []apismetal.NetworkPolicyRule{
+						{
+							CIDRs: []string{"10.0.0.0/24", "2001:db8::/32"},
+							Ports: []apismetal.NetworkPolicyPort{{Protocol: corev1.ProtocolTCP, Port: 5432}},
+						},
+					},
+				},
+				{
+					Name: "allow-from-monitoring",
+					Ingress: []apismetal.NetworkPolicyRule{
+						{CIDRs: []string{"10.1.0.0/24"}},
+					},
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, cloudProfile, field.NewPath("spec"))).To(BeEmpty())
+		})
+
+		It("should only allow disabling supported default network policies", func() {
+			controlPlaneConfig.DisabledDefaultNetworkPolicies = []string{
+				apismetal.DefaultNetworkPolicyAllowToHTTP,
+				apismetal.DefaultNetworkPolicyAllowToVPN,
+				apismetal.DefaultNetworkPolicyAllowToHTTP,
+				"allow-to-anything",
+			}
+
+			errorList := ValidateControlPlaneConfig(controlPlaneConfig, cloudProfile, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("spec.disabledDefaultNetworkPolicies[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("spec.disabledDefaultNetworkPolicies[2]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("spec.disabledDefaultNetworkPolicies[3]"),
+				})),
+			))
+		})
+
+		It("should forbid invalid network policies", func() {
+			controlPlaneConfig.NetworkPolicies = []apismetal.NetworkPolicy{
+				{
+					Name: "allow-to-dns",
+					Egress: []apismetal.NetworkPolicyRule{
+						{
+							CIDRs: []string{"10.0.0"},
+							Ports: []apismetal.NetworkPolicyPort{{Protocol: corev1.ProtocolSCTP, Port: 0}},
+						},
+					},
+				},
+				{
+					Name: "allow-to-dns",
+					Ingress: []apismetal.NetworkPolicyRule{
+						{},
+					},
+				},
+				{
+					Name: "Not_Valid",
+				},
+			}
+
+			errorList := ValidateControlPlaneConfig(controlPlaneConfig, cloudProfile, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("spec.networkPolicies[0].name"),
+					"Detail": Equal("name \"allow-to-dns\" is reserved for a default network policy of the cluster"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("spec.networkPolicies[0].egress[0].cidrs[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("spec.networkPolicies[0].egress[0].ports[0].protocol"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("spec.networkPolicies[0].egress[0].ports[0].port"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("spec.networkPolicies[1].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("spec.networkPolicies[1].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("spec.networkPolicies[1].ingress[0].cidrs"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("spec.networkPolicies[2].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("spec.networkPolicies[2]"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfigUpdate", func() {
+		It("should forbid changing the network of a static ip reservation", func() {
+			oldConfig := controlPlaneConfig.DeepCopy()
+			oldConfig.StaticIPReservations = []apismetal.StaticIPReservation{{Name: "ingress", Network: "internet"}}
+			controlPlaneConfig.StaticIPReservations = []apismetal.StaticIPReservation{
+				{Name: "mail", Network: "mpls"},
+				{Name: "ingress", Network: "mpls"},
+			}
+
+			errorList := ValidateControlPlaneConfigUpdate(oldConfig, controlPlaneConfig, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("spec.staticIPReservations[1]"),
+			}))))
+		})
+
+		It("should forbid making a static ip reservation ephemeral", func() {
+			oldConfig := controlPlaneConfig.DeepCopy()
+			oldConfig.StaticIPReservations = []apismetal.StaticIPReservation{{Name: "ingress", Network: "internet"}}
+			controlPlaneConfig.StaticIPReservations = []apismetal.StaticIPReservation{{Name: "ingress", Network: "internet", Ephemeral: true}}
+
+			errorList := ValidateControlPlaneConfigUpdate(oldConfig, controlPlaneConfig, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("spec.staticIPReservations[0]"),
+			}))))
+		})
+
+		It("should allow adding and removing static ip reservations", func() {
+			oldConfig := controlPlaneConfig.DeepCopy()
+			oldConfig.StaticIPReservations = []apismetal.StaticIPReservation{{Name: "ingress", Network: "internet"}}
+			controlPlaneConfig.StaticIPReservations = []apismetal.StaticIPReservation{{Name: "mail", Network: "internet"}}
+
+			Expect(ValidateControlPlaneConfigUpdate(oldConfig, controlPlaneConfig, field.NewPath("spec"))).To(BeEmpty())
+		})
+
+		It("should forbid changing the network access type from baseline to forbidden", func() {
+			oldConfig := controlPlaneConfig.DeepCopy()
+			controlPlaneConfig.NetworkAccessType = new(apismetal.NetworkAccessForbidden)
+
+			errorList := ValidateControlPlaneConfigUpdate(oldConfig, controlPlaneConfig, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeForbidden),
+				"Field":  Equal("spec.networkAccessType"),
+				"Detail": Equal("network access type cannot be changed from baseline to forbidden directly, the cluster needs to be migrated to restricted first"),
+			}))))
+		})
+
+		It("should allow changing the network access type step by step", func() {
+			oldConfig := controlPlaneConfig.DeepCopy()
+			controlPlaneConfig.NetworkAccessType = new(apismetal.NetworkAccessRestricted)
+
+			Expect(ValidateControlPlaneConfigUpdate(oldConfig, controlPlaneConfig, field.NewPath("spec"))).To(BeEmpty())
+
+			oldConfig = controlPlaneConfig.DeepCopy()
+			controlPlaneConfig.NetworkAccessType = new(apismetal.NetworkAccessForbidden)
+
+			Expect(ValidateControlPlaneConfigUpdate(oldConfig, controlPlaneConfig, field.NewPath("spec"))).To(BeEmpty())
+
+			oldConfig = controlPlaneConfig.DeepCopy()
+			controlPlaneConfig.NetworkAccessType = new(apismetal.NetworkAccessBaseline)
+
+			Expect(ValidateControlPlaneConfigUpdate(oldConfig, controlPlaneConfig, field.NewPath("spec"))).To(BeEmpty())
+		})
 	})
 })
@@ -3,9 +3,13 @@ package validation
 import (
 	"fmt"
 	"net"
+	"net/netip"
+	"slices"
 
 	"github.com/gardener/gardener/pkg/apis/core"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/metal-stack/metal-go/api/models"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -52,6 +56,14 @@ func ValidateInfrastructureConfigAgainstCloudProfile(infra *apismetal.Infrastruc
 		allErrs = append(allErrs, field.Invalid(firewallPath.Child("size"), infra.Firewall.Size, fmt.Sprintf("supported values: %v", availableFirewallTypes.List())))
 	}
 
+	if p.MaxRateLimit != nil {
+		for i, rateLimit := range infra.Firewall.RateLimits {
+			if rateLimit.RateLimit > *p.MaxRateLimit {
+				allErrs = append(allErrs, field.Invalid(firewallPath.Child("rateLimits").Index(i).Child("rateLimit"), rateLimit.RateLimit, fmt.Sprintf("rate limit must not exceed the maximum of the partition (%d Mbit/s)", *p.MaxRateLimit)))
+			}
+		}
+	}
+
 	var availableFirewallControllerVersions []apismetal.FirewallControllerVersion
 	availableFirewallControllerVersions = append(
 		availableFirewallControllerVersions,
@@ -113,6 +125,7 @@ func ValidateInfrastructureConfig(infra *apismetal.InfrastructureConfig) field.E
 		availableNetworks.Insert(network)
 	}
 
+	rateLimitNetworks := sets.NewString()
 	for i, rateLimit := range infra.Firewall.RateLimits {
 		fp := firewallPath.Child("rateLimit").Index(i)
 		if rateLimit.NetworkID == "" {
@@ -123,6 +136,11 @@ func ValidateInfrastructureConfig(infra *apismetal.InfrastructureConfig) field.E
 			allErrs = append(allErrs, field.Required(fp, "rate limit network must be present as cluster network"))
 			continue
 		}
+		if rateLimitNetworks.Has(rateLimit.NetworkID) {
+			allErrs = append(allErrs, field.Duplicate(fp.Child("networkID"), rateLimit.NetworkID))
+			continue
+		}
+		rateLimitNetworks.Insert(rateLimit.NetworkID)
 	}
 
 	for i, egress := range infra.Firewall.EgressRules {
@@ -149,6 +167,114 @@ func ValidateInfrastructureConfig(infra *apismetal.InfrastructureConfig) field.E
 	return allErrs
 }
 
+// ValidateInfrastructureConfigAgainstNetworks validates the firewall egress rules of the given `InfrastructureConfig` against
+// the networks of the metal-api. The referenced networks must be external networks and the egress ips must be contained in
+// their prefixes.
+func ValidateInfrastructureConfigAgainstNetworks(infra *apismetal.InfrastructureConfig, networks map[string]*models.V1NetworkResponse, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	egressRulesPath := fldPath.Child("firewall", "egressRules")
+
+	for i, egress := range infra.Firewall.EgressRules {
+		fp := egressRulesPath.Index(i)
+
+		nw, ok := networks[egress.NetworkID]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(fp.Child("networkID"), egress.NetworkID))
+			continue
+		}
+
+		if !isExternalNetwork(nw) {
+			allErrs = append(allErrs, field.Invalid(fp.Child("networkID"), egress.NetworkID, "egress rule network must be an external network"))
+			continue
+		}
+
+		var prefixes []netip.Prefix
+		for _, p := range nw.Prefixes {
+			prefix, err := netip.ParsePrefix(p)
+			if err != nil {
+				continue
+			}
+			prefixes = append(prefixes, prefix)
+		}
+
+		for j, ip := range egress.IPs {
+			addr, err := netip.ParseAddr(ip)
+			if err != nil {
+				// malformed ip addresses are already reported by ValidateInfrastructureConfig
+				continue
+			}
+
+			if !slices.ContainsFunc(prefixes, func(prefix netip.Prefix) bool { return prefix.Contains(addr) }) {
+				allErrs = append(allErrs, field.Invalid(fp.Child("ips").Index(j), ip, fmt.Sprintf("ip is not contained in the prefixes of network %q: %v", egress.NetworkID, nw.Prefixes)))
+			}
+		}
+	}
+
+	rateLimitsPath := fldPath.Child("firewall", "rateLimits")
+
+	for i, rateLimit := range infra.Firewall.RateLimits {
+		fp := rateLimitsPath.Index(i).Child("networkID")
+
+		nw, ok := networks[rateLimit.NetworkID]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(fp, rateLimit.NetworkID))
+			continue
+		}
+
+		if !isExternalNetwork(nw) {
+			allErrs = append(allErrs, field.Invalid(fp, rateLimit.NetworkID, "rate limit network must be an external network"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateDefaultExternalNetworks validates the default external networks of the given partition against the networks
+// of the metal-api. They must be external networks and, for shoots with a restricted or forbidden network access type,
+// their destinations must be contained in the allowed egress networks of the partition.
+func ValidateDefaultExternalNetworks(partition *apismetal.Partition, networkAccessType apismetal.NetworkAccessType, networks map[string]*models.V1NetworkResponse, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var allowedEgress []netip.Prefix
+	isolated := networkAccessType != "" && networkAccessType != apismetal.NetworkAccessBaseline
+	if isolated && partition.NetworkIsolation != nil {
+		allowedEgress = parseMaskedPrefixes(partition.NetworkIsolation.AllowedNetworks.Egress)
+	}
+
+	for i, networkID := range partition.DefaultExternalNetworks {
+		fp := fldPath.Index(i)
+
+		nw, ok := networks[networkID]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(fp, networkID))
+			continue
+		}
+
+		if !isExternalNetwork(nw) {
+			allErrs = append(allErrs, field.Invalid(fp, networkID, "default external network must be an external network"))
+			continue
+		}
+
+		if !isolated || partition.NetworkIsolation == nil {
+			continue
+		}
+
+		for _, destination := range nw.Destinationprefixes {
+			prefix, err := netip.ParsePrefix(destination)
+			if err != nil || !prefixContainedInAny(prefix.Masked(), allowedEgress) {
+				allErrs = append(allErrs, field.Invalid(fp, networkID, fmt.Sprintf("destination %q of the default external network is not contained in the allowed egress networks of the partition", destination)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func isExternalNetwork(nw *models.V1NetworkResponse) bool {
+	return nw.Parentnetworkid == "" && !pointer.SafeDeref(nw.Privatesuper) && !pointer.SafeDeref(nw.Underlay)
+}
+
 // ValidateInfrastructureConfigUpdate validates a InfrastructureConfig object.
 func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *apismetal.InfrastructureConfig, cloudProfileConfig *apismetal.CloudProfileConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -3,6 +3,7 @@ package validation_test
 import (
 	"github.com/gardener/gardener/pkg/apis/core"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/metal-stack/metal-go/api/models"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
@@ -106,6 +107,202 @@ var _ = Describe("InfrastructureConfig validation", func() {
 				}))))
 			})
 		})
+
+		Context("rate limit validation", func() {
+			BeforeEach(func() {
+				shoot = &core.Shoot{}
+
+				cloudProfile = &gardencorev1beta1.CloudProfile{}
+				cloudProfileConfig = createCloudProfileConfig()
+
+				partition := cloudProfileConfig.MetalControlPlanes["prod"].Partitions["partition-a"]
+				partition.MaxRateLimit = new(uint32(1000))
+				cloudProfileConfig.MetalControlPlanes["prod"].Partitions["partition-a"] = partition
+			})
+
+			It("should pass when rate limits are within the partition maximum", func() {
+				infrastructureConfig.Firewall.RateLimits = []apismetal.RateLimit{{NetworkID: "internet", RateLimit: 1000}}
+
+				errorList := ValidateInfrastructureConfigAgainstCloudProfile(infrastructureConfig, shoot, cloudProfile, cloudProfileConfig, field.NewPath("spec"))
+
+				Expect(errorList).NotTo(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Field": Equal("spec.firewall.rateLimits[0].rateLimit"),
+				}))))
+			})
+
+			It("should forbid rate limits exceeding the partition maximum", func() {
+				infrastructureConfig.Firewall.RateLimits = []apismetal.RateLimit{{NetworkID: "internet", RateLimit: 1001}}
+
+				errorList := ValidateInfrastructureConfigAgainstCloudProfile(infrastructureConfig, shoot, cloudProfile, cloudProfileConfig, field.NewPath("spec"))
+
+				Expect(errorList).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("spec.firewall.rateLimits[0].rateLimit"),
+					"Detail": Equal("rate limit must not exceed the maximum of the partition (1000 Mbit/s)"),
+				}))))
+			})
+		})
+	})
+
+	Describe("#ValidateInfrastructureConfigAgainstNetworks", func() {
+		var (
+			networks map[string]*models.V1NetworkResponse
+		)
+
+		BeforeEach(func() {
+			networks = map[string]*models.V1NetworkResponse{
+				"internet": {
+					ID:       new("internet"),
+					Prefixes: []string{"185.1.2.0/24", "2a02:c00::/64"},
+				},
+				"tenant-super": {
+					ID:           new("tenant-super"),
+					Prefixes:     []string{"10.0.0.0/16"},
+					Privatesuper: new(true),
+				},
+				"dmz": {
+					ID:              new("dmz"),
+					Parentnetworkid: "tenant-super",
+					Prefixes:        []string{"10.0.1.0/24"},
+				},
+			}
+		})
+
+		It("should pass when egress ips are contained in the external network", func() {
+			infrastructureConfig.Firewall.EgressRules = []apismetal.EgressRule{{NetworkID: "internet", IPs: []string{"185.1.2.3", "2a02:c00::1"}}}
+
+			errorList := ValidateInfrastructureConfigAgainstNetworks(infrastructureConfig, networks, field.NewPath("spec"))
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid egress ips outside of the network prefixes", func() {
+			infrastructureConfig.Firewall.EgressRules = []apismetal.EgressRule{{NetworkID: "internet", IPs: []string{"185.1.2.3", "185.1.3.1"}}}
+
+			errorList := ValidateInfrastructureConfigAgainstNetworks(infrastructureConfig, networks, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("spec.firewall.egressRules[0].ips[1]"),
+				"BadValue": Equal("185.1.3.1"),
+			}))))
+		})
+
+		It("should forbid egress rules for non-external networks", func() {
+			infrastructureConfig.Firewall.EgressRules = []apismetal.EgressRule{{NetworkID: "dmz", IPs: []string{"10.0.1.1"}}}
+
+			errorList := ValidateInfrastructureConfigAgainstNetworks(infrastructureConfig, networks, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("spec.firewall.egressRules[0].networkID"),
+				"Detail": Equal("egress rule network must be an external network"),
+			}))))
+		})
+
+		It("should forbid egress rules for unknown networks", func() {
+			infrastructureConfig.Firewall.EgressRules = []apismetal.EgressRule{{NetworkID: "unknown", IPs: []string{"10.0.1.1"}}}
+
+			errorList := ValidateInfrastructureConfigAgainstNetworks(infrastructureConfig, networks, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotFound),
+				"Field": Equal("spec.firewall.egressRules[0].networkID"),
+			}))))
+		})
+
+		It("should pass when rate limits reference an external network", func() {
+			infrastructureConfig.Firewall.RateLimits = []apismetal.RateLimit{{NetworkID: "internet", RateLimit: 100}}
+
+			errorList := ValidateInfrastructureConfigAgainstNetworks(infrastructureConfig, networks, field.NewPath("spec"))
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid rate limits for non-external and unknown networks", func() {
+			infrastructureConfig.Firewall.RateLimits = []apismetal.RateLimit{{NetworkID: "dmz", RateLimit: 100}, {NetworkID: "unknown", RateLimit: 100}}
+
+			errorList := ValidateInfrastructureConfigAgainstNetworks(infrastructureConfig, networks, field.NewPath("spec"))
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("spec.firewall.rateLimits[0].networkID"),
+					"Detail": Equal("rate limit network must be an external network"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotFound),
+					"Field": Equal("spec.firewall.rateLimits[1].networkID"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateDefaultExternalNetworks", func() {
+		var (
+			networks  map[string]*models.V1NetworkResponse
+			partition *apismetal.Partition
+		)
+
+		BeforeEach(func() {
+			networks = map[string]*models.V1NetworkResponse{
+				"internet": {
+					ID:                  new("internet"),
+					Destinationprefixes: []string{"0.0.0.0/0"},
+				},
+				"mpls": {
+					ID:                  new("mpls"),
+					Destinationprefixes: []string{"100.127.0.0/16"},
+				},
+				"tenant-super": {
+					ID:           new("tenant-super"),
+					Privatesuper: new(true),
+				},
+			}
+			partition = &apismetal.Partition{
+				DefaultExternalNetworks: []string{"internet", "mpls"},
+				NetworkIsolation: &apismetal.NetworkIsolation{
+					AllowedNetworks: apismetal.AllowedNetworks{
+						Egress: []string{"100.127.0.0/15"},
+					},
+				},
+			}
+		})
+
+		It("should pass for external networks of baseline shoots", func() {
+			errorList := ValidateDefaultExternalNetworks(partition, apismetal.NetworkAccessBaseline, networks, field.NewPath("defaultExternalNetworks"))
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid unknown and non-external networks", func() {
+			partition.DefaultExternalNetworks = []string{"unknown", "tenant-super"}
+
+			errorList := ValidateDefaultExternalNetworks(partition, apismetal.NetworkAccessBaseline, networks, field.NewPath("defaultExternalNetworks"))
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotFound),
+					"Field": Equal("defaultExternalNetworks[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("defaultExternalNetworks[1]"),
+					"Detail": Equal("default external network must be an external network"),
+				})),
+			))
+		})
+
+		It("should forbid networks whose destinations are not allowed for isolated shoots", func() {
+			errorList := ValidateDefaultExternalNetworks(partition, apismetal.NetworkAccessForbidden, networks, field.NewPath("defaultExternalNetworks"))
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("defaultExternalNetworks[0]"),
+				})),
+			))
+		})
 	})
 
 	Describe("#ValidateInfrastructureConfig", func() {
@@ -170,6 +367,22 @@ var _ = Describe("InfrastructureConfig validation", func() {
 				}))))
 			})
 		})
+
+		Context("Rate limits", func() {
+			It("should forbid duplicate rate limits for a network", func() {
+				infrastructureConfig.Firewall.RateLimits = []apismetal.RateLimit{
+					{NetworkID: "internet", RateLimit: 100},
+					{NetworkID: "internet", RateLimit: 200},
+				}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("firewall.rateLimit[1].networkID"),
+				}))))
+			})
+		})
 	})
 
 	Describe("#ValidateInfrastructureConfigUpdate", func() {
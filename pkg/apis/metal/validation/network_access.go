@@ -2,8 +2,11 @@ package validation
 
 import (
 	"fmt"
+	"net/netip"
+	"slices"
 
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -16,13 +19,122 @@ func ValidateControlPlaneConfigNetworkAccess(controlPlaneConfig *apismetal.Contr
 		return append(allErrs, errs...)
 	}
 	allErrs = append(allErrs, validateNetworkAccessFields(controlPlaneConfig, fldPath, partition, partPath)...)
+	// the default network policies of the seed are only known to the extension running in the seed, which reports
+	// an incompatibility with them during the reconciliation of the control plane
+	allErrs = append(allErrs, ValidateNetworkServerReachability(controlPlaneConfig, partition, nil, fldPath)...)
 
 	return allErrs
 }
 
+// ValidateNetworkServerReachability ensures that dns and ntp servers remain reachable from the cluster when their
+// default network policies are not deployed with the given default network policies of the seed. in this case the
+// network policies of the control plane config need to allow the traffic instead.
+func ValidateNetworkServerReachability(controlPlaneConfig *apismetal.ControlPlaneConfig, partition *apismetal.Partition, seedDefaultNetworkPolicies []string, cpcPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	defaults := helper.DefaultNetworkPolicies(seedDefaultNetworkPolicies, partition, controlPlaneConfig)
+	isolated := controlPlaneConfig.NetworkAccessType != nil && *controlPlaneConfig.NetworkAccessType != apismetal.NetworkAccessBaseline
+
+	type service struct {
+		policy           string
+		name             string
+		defaultPort      int32
+		defaultProtocols []apismetal.NetworkServerProtocol
+		servers          []apismetal.NetworkServer
+	}
+
+	services := []service{
+		{
+			policy:           apismetal.DefaultNetworkPolicyAllowToDNS,
+			name:             "dns",
+			defaultPort:      helper.DefaultDNSPort,
+			defaultProtocols: []apismetal.NetworkServerProtocol{apismetal.NetworkServerProtocolUDP, apismetal.NetworkServerProtocolTCP},
+		},
+		{
+			policy:           apismetal.DefaultNetworkPolicyAllowToNTP,
+			name:             "ntp",
+			defaultPort:      helper.DefaultNTPPort,
+			defaultProtocols: []apismetal.NetworkServerProtocol{apismetal.NetworkServerProtocolUDP},
+		},
+	}
+	if isolated && partition.NetworkIsolation != nil {
+		services[0].servers = partition.NetworkIsolation.DNSServers
+		services[1].servers = partition.NetworkIsolation.NTPServers
+	}
+
+	policiesPath := cpcPath.Child("networkPolicies")
+
+	for _, svc := range services {
+		if slices.Contains(defaults, svc.policy) {
+			continue
+		}
+
+		if !isolated {
+			// the servers of baseline clusters are not known, so any destination on the service port needs to be allowed
+			if !networkPoliciesAllowEgress(controlPlaneConfig.NetworkPolicies, nil, svc.defaultPort, svc.defaultProtocols) {
+				allErrs = append(allErrs, field.Required(policiesPath, fmt.Sprintf("%s servers must be reachable through a network policy on port %d when %s is not deployed", svc.name, svc.defaultPort, svc.policy)))
+			}
+			continue
+		}
+
+		for _, server := range svc.servers {
+			addr, err := netip.ParseAddr(server.IP)
+			if err != nil {
+				// invalid servers are reported by the cloud profile validation
+				continue
+			}
+
+			port := svc.defaultPort
+			protocols := helper.NetworkServerProtocols(server, svc.defaultProtocols...)
+
+			if !networkPoliciesAllowEgress(controlPlaneConfig.NetworkPolicies, &addr, port, protocols) {
+				allErrs = append(allErrs, field.Required(policiesPath, fmt.Sprintf("%s server %s must be reachable through a network policy on port %d when %s is not deployed", svc.name, server.IP, port, svc.policy)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// networkPoliciesAllowEgress returns true if any egress rule of the given policies allows traffic to the given address
+// and port with one of the given protocols. if no address is given, the rule must allow traffic to any destination.
+func networkPoliciesAllowEgress(policies []apismetal.NetworkPolicy, addr *netip.Addr, port int32, protocols []apismetal.NetworkServerProtocol) bool {
+	for _, p := range policies {
+		for _, rule := range p.Egress {
+			destinationAllowed := slices.ContainsFunc(parseMaskedPrefixes(rule.CIDRs), func(prefix netip.Prefix) bool {
+				if addr == nil {
+					return prefix.Bits() == 0
+				}
+				return prefix.Contains(*addr)
+			})
+			if !destinationAllowed {
+				continue
+			}
+
+			if len(rule.Ports) == 0 {
+				return true
+			}
+
+			for _, rulePort := range rule.Ports {
+				if rulePort.Port == port && slices.Contains(protocols, apismetal.NetworkServerProtocol(rulePort.Protocol)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func validateNetworkAccessFields(controlPlaneConfig *apismetal.ControlPlaneConfig, cpcPath *field.Path, partition *apismetal.Partition, partPath *field.Path) field.ErrorList {
 
+	aanPath := cpcPath.Child("additionalAllowedNetworks")
+
 	if controlPlaneConfig.NetworkAccessType == nil || *controlPlaneConfig.NetworkAccessType == apismetal.NetworkAccessBaseline {
+		if controlPlaneConfig.AdditionalAllowedNetworks != nil {
+			return field.ErrorList{
+				field.Forbidden(aanPath, "additional allowed networks can only be set for network access type restricted or forbidden"),
+			}
+		}
 		return nil
 	}
 
@@ -35,11 +147,113 @@ func validateNetworkAccessFields(controlPlaneConfig *apismetal.ControlPlaneConfi
 			field.Invalid(natPath, controlPlaneConfig.NetworkAccessType, "network access type requires partition's networkIsolation to be set"),
 			field.Required(partNiPath, "network isolation required if control plane config networkAccess is not baseline"),
 		)
+		return allErrs
+	}
+
+	if controlPlaneConfig.AdditionalAllowedNetworks != nil {
+		approvable := apismetal.AllowedNetworks{}
+		if partition.NetworkIsolation.ApprovableNetworks != nil {
+			approvable = *partition.NetworkIsolation.ApprovableNetworks
+		}
+
+		allErrs = append(allErrs, validateAdditionalNetworks(controlPlaneConfig.AdditionalAllowedNetworks.Egress, approvable.Egress, aanPath.Child("egress"))...)
+		allErrs = append(allErrs, validateAdditionalNetworks(controlPlaneConfig.AdditionalAllowedNetworks.Ingress, approvable.Ingress, aanPath.Child("ingress"))...)
+	}
+
+	if *controlPlaneConfig.NetworkAccessType == apismetal.NetworkAccessForbidden {
+		allErrs = append(allErrs, validateForbiddenNetworkPolicies(controlPlaneConfig, partition.NetworkIsolation, cpcPath.Child("networkPolicies"))...)
 	}
 
 	return allErrs
 }
 
+// validateForbiddenNetworkPolicies ensures that the network policies of a forbidden cluster only allow traffic which
+// is permitted by the firewall anyway, otherwise the policies would silently have no effect.
+func validateForbiddenNetworkPolicies(controlPlaneConfig *apismetal.ControlPlaneConfig, networkIsolation *apismetal.NetworkIsolation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allowed := networkIsolation.AllowedNetworks
+	egress := allowed.Egress
+	ingress := allowed.Ingress
+	if controlPlaneConfig.AdditionalAllowedNetworks != nil {
+		egress = append(slices.Clone(egress), controlPlaneConfig.AdditionalAllowedNetworks.Egress...)
+		ingress = append(slices.Clone(ingress), controlPlaneConfig.AdditionalAllowedNetworks.Ingress...)
+	}
+
+	for i, p := range controlPlaneConfig.NetworkPolicies {
+		for j, rule := range p.Egress {
+			allErrs = append(allErrs, validateAllowedNetworkPolicyCIDRs(rule.CIDRs, egress, fldPath.Index(i).Child("egress").Index(j).Child("cidrs"))...)
+		}
+		for j, rule := range p.Ingress {
+			allErrs = append(allErrs, validateAllowedNetworkPolicyCIDRs(rule.CIDRs, ingress, fldPath.Index(i).Child("ingress").Index(j).Child("cidrs"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateAllowedNetworkPolicyCIDRs(cidrs []string, allowedCidrs []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allowed := parseMaskedPrefixes(allowedCidrs)
+
+	for index, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			// invalid cidrs are already reported by ValidateControlPlaneConfig
+			continue
+		}
+
+		if !prefixContainedInAny(prefix.Masked(), allowed) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(index), fmt.Sprintf("network %q is not contained in the allowed networks of the cluster", cidr)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAdditionalNetworks(cidrs []string, approvableCidrs []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	approvable := parseMaskedPrefixes(approvableCidrs)
+
+	for index, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(index), cidr, "invalid cidr"))
+			continue
+		}
+
+		if !prefixContainedInAny(prefix.Masked(), approvable) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(index), fmt.Sprintf("network %q is not contained in the approvable networks of the partition", cidr)))
+		}
+	}
+
+	return allErrs
+}
+
+// parseMaskedPrefixes parses the given cidrs and skips invalid ones, which are reported by the validation of their origin.
+func parseMaskedPrefixes(cidrs []string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+func prefixContainedInAny(prefix netip.Prefix, prefixes []netip.Prefix) bool {
+	for _, p := range prefixes {
+		if p.Bits() <= prefix.Bits() && p.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
 func findMetalControlPlane(cloudProfileConfig *apismetal.CloudProfileConfig, partition string, cpcPath *field.Path) (*apismetal.Partition, *field.Path, field.ErrorList) {
 	for mcpName, mcp := range cloudProfileConfig.MetalControlPlanes {
 		for partitionName, p := range mcp.Partitions {
@@ -4,6 +4,7 @@ import (
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 
 	. "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	. "github.com/onsi/ginkgo"
@@ -56,6 +57,29 @@ var _ = Describe("CloudProfileConfig validation", func() {
 
 				Expect(errorList).To(BeEmpty())
 			})
+
+			It("should forbid additional allowed networks", func() {
+				cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+					"prod": {
+						Partitions: map[string]apismetal.Partition{
+							"partition-b": {},
+						},
+					},
+				}
+				controlPlaneConfig.AdditionalAllowedNetworks = &apismetal.AllowedNetworks{
+					Egress: []string{"100.0.0.0/24"},
+				}
+
+				errorList := ValidateControlPlaneConfigNetworkAccess(controlPlaneConfig, cloudProfileConfig, partitionName, path)
+
+				Expect(errorList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeForbidden),
+						"Field":  Equal("test.additionalAllowedNetworks"),
+						"Detail": Equal("additional allowed networks can only be set for network access type restricted or forbidden"),
+					})),
+				))
+			})
 		})
 
 		Describe("with network access type forbidden", func() {
@@ -100,8 +124,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 										Ingress: []string{"10.0.0.1/24"},
 										Egress:  []string{"100.0.0.1/24"},
 									},
-									DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-									NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+									DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+									NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 									RegistryMirrors: []apismetal.RegistryMirror{
 										{
 											Name:     "metal-stack registry",
@@ -124,6 +148,101 @@ var _ = Describe("CloudProfileConfig validation", func() {
 
 				Expect(errorList).To(BeEmpty())
 			})
+
+			It("should only allow additional allowed networks within the approvable networks", func() {
+				cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+					"prod": {
+						Partitions: map[string]apismetal.Partition{
+							"partition-b": {
+								NetworkIsolation: &apismetal.NetworkIsolation{
+									AllowedNetworks: apismetal.AllowedNetworks{
+										Ingress: []string{"10.0.0.1/24"},
+										Egress:  []string{"100.0.0.1/24"},
+									},
+									ApprovableNetworks: &apismetal.AllowedNetworks{
+										Ingress: []string{"10.1.0.0/16"},
+										Egress:  []string{"200.0.0.0/16", "2001:db8::/32"},
+									},
+								},
+							},
+						},
+					},
+				}
+				controlPlaneConfig.AdditionalAllowedNetworks = &apismetal.AllowedNetworks{
+					Ingress: []string{"10.2.0.0/24"},
+					Egress:  []string{"200.0.1.0/24", "200.0.0.0/8", "2001:db8:1::/48", "200.0.0"},
+				}
+
+				errorList := ValidateControlPlaneConfigNetworkAccess(controlPlaneConfig, cloudProfileConfig, partitionName, path)
+
+				Expect(errorList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeForbidden),
+						"Field":  Equal("test.additionalAllowedNetworks.egress[1]"),
+						"Detail": Equal("network \"200.0.0.0/8\" is not contained in the approvable networks of the partition"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":     Equal(field.ErrorTypeInvalid),
+						"Field":    Equal("test.additionalAllowedNetworks.egress[3]"),
+						"BadValue": Equal("200.0.0"),
+						"Detail":   Equal("invalid cidr"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeForbidden),
+						"Field":  Equal("test.additionalAllowedNetworks.ingress[0]"),
+						"Detail": Equal("network \"10.2.0.0/24\" is not contained in the approvable networks of the partition"),
+					})),
+				))
+			})
+
+			It("should only allow network policies within the allowed networks", func() {
+				cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+					"prod": {
+						Partitions: map[string]apismetal.Partition{
+							"partition-b": {
+								NetworkIsolation: &apismetal.NetworkIsolation{
+									AllowedNetworks: apismetal.AllowedNetworks{
+										Ingress: []string{"10.0.0.0/24"},
+										Egress:  []string{"100.0.0.0/24"},
+									},
+									ApprovableNetworks: &apismetal.AllowedNetworks{
+										Egress: []string{"200.0.0.0/16"},
+									},
+								},
+							},
+						},
+					},
+				}
+				controlPlaneConfig.AdditionalAllowedNetworks = &apismetal.AllowedNetworks{
+					Egress: []string{"200.0.1.0/24"},
+				}
+				controlPlaneConfig.NetworkPolicies = []apismetal.NetworkPolicy{
+					{
+						Name: "allow-to-backends",
+						Egress: []apismetal.NetworkPolicyRule{
+							{CIDRs: []string{"100.0.0.8/29", "200.0.1.0/24", "200.0.2.0/24"}},
+						},
+						Ingress: []apismetal.NetworkPolicyRule{
+							{CIDRs: []string{"10.0.0.0/24", "10.1.0.0/24"}},
+						},
+					},
+				}
+
+				errorList := ValidateControlPlaneConfigNetworkAccess(controlPlaneConfig, cloudProfileConfig, partitionName, path)
+
+				Expect(errorList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeForbidden),
+						"Field":  Equal("test.networkPolicies[0].egress[0].cidrs[2]"),
+						"Detail": Equal("network \"200.0.2.0/24\" is not contained in the allowed networks of the cluster"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeForbidden),
+						"Field":  Equal("test.networkPolicies[0].ingress[0].cidrs[1]"),
+						"Detail": Equal("network \"10.1.0.0/24\" is not contained in the allowed networks of the cluster"),
+					})),
+				))
+			})
 		})
 
 		Describe("with network access type restricted", func() {
@@ -168,8 +287,8 @@ var _ = Describe("CloudProfileConfig validation", func() {
 										Ingress: []string{"10.0.0.1/24"},
 										Egress:  []string{"100.0.0.1/24"},
 									},
-									DNSServers: []string{"1.1.1.1", "1.0.0.1"},
-									NTPServers: []string{"134.60.1.27", "134.60.111.110"},
+									DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+									NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}, {IP: "134.60.111.110"}},
 									RegistryMirrors: []apismetal.RegistryMirror{
 										{
 											Name:     "metal-stack registry",
@@ -192,6 +311,124 @@ var _ = Describe("CloudProfileConfig validation", func() {
 
 				Expect(errorList).To(BeEmpty())
 			})
+
+			It("should require dns and ntp servers to be reachable when their default policies are disabled", func() {
+				cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+					"prod": {
+						Partitions: map[string]apismetal.Partition{
+							"partition-b": {
+								NetworkIsolation: &apismetal.NetworkIsolation{
+									AllowedNetworks: apismetal.AllowedNetworks{
+										Ingress: []string{"10.0.0.1/24"},
+										Egress:  []string{"100.0.0.1/24"},
+									},
+									DNSServers: []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "1.0.0.1"}},
+									NTPServers: []apismetal.NetworkServer{{IP: "134.60.1.27"}},
+								},
+							},
+						},
+					},
+				}
+				controlPlaneConfig.DisabledDefaultNetworkPolicies = []string{apismetal.DefaultNetworkPolicyAllowToDNS, apismetal.DefaultNetworkPolicyAllowToNTP}
+				controlPlaneConfig.NetworkPolicies = []apismetal.NetworkPolicy{
+					{
+						Name: "allow-to-resolvers",
+						Egress: []apismetal.NetworkPolicyRule{
+							{
+								CIDRs: []string{"1.1.1.1/32"},
+								Ports: []apismetal.NetworkPolicyPort{{Protocol: corev1.ProtocolUDP, Port: 53}},
+							},
+							{
+								CIDRs: []string{"1.0.0.1/32"},
+								Ports: []apismetal.NetworkPolicyPort{{Protocol: corev1.ProtocolUDP, Port: 5353}},
+							},
+						},
+					},
+				}
+
+				errorList := ValidateControlPlaneConfigNetworkAccess(controlPlaneConfig, cloudProfileConfig, partitionName, path)
+
+				Expect(errorList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeRequired),
+						"Field":  Equal("test.networkPolicies"),
+						"Detail": Equal("dns server 1.0.0.1 must be reachable through a network policy on port 53 when allow-to-dns is not deployed"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeRequired),
+						"Field":  Equal("test.networkPolicies"),
+						"Detail": Equal("ntp server 134.60.1.27 must be reachable through a network policy on port 123 when allow-to-ntp is not deployed"),
+					})),
+				))
+			})
+		})
+
+		Describe("with default network policies", func() {
+			BeforeEach(func() {
+				cloudProfileConfig.MetalControlPlanes = map[string]apismetal.MetalControlPlane{
+					"prod": {
+						Partitions: map[string]apismetal.Partition{
+							"partition-b": {},
+						},
+					},
+				}
+			})
+
+			It("should require dns to be reachable in baseline clusters when its default policy is disabled", func() {
+				controlPlaneConfig.DisabledDefaultNetworkPolicies = []string{apismetal.DefaultNetworkPolicyAllowToDNS}
+
+				errorList := ValidateControlPlaneConfigNetworkAccess(controlPlaneConfig, cloudProfileConfig, partitionName, path)
+
+				Expect(errorList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeRequired),
+						"Field":  Equal("test.networkPolicies"),
+						"Detail": Equal("dns servers must be reachable through a network policy on port 53 when allow-to-dns is not deployed"),
+					})),
+				))
+			})
+
+			It("should pass in baseline clusters when dns is allowed by a network policy", func() {
+				controlPlaneConfig.DisabledDefaultNetworkPolicies = []string{apismetal.DefaultNetworkPolicyAllowToDNS}
+				controlPlaneConfig.NetworkPolicies = []apismetal.NetworkPolicy{
+					{
+						Name: "allow-to-dns-anywhere",
+						Egress: []apismetal.NetworkPolicyRule{
+							{
+								CIDRs: []string{"0.0.0.0/0"},
+								Ports: []apismetal.NetworkPolicyPort{{Protocol: corev1.ProtocolUDP, Port: 53}},
+							},
+						},
+					},
+				}
+
+				errorList := ValidateControlPlaneConfigNetworkAccess(controlPlaneConfig, cloudProfileConfig, partitionName, path)
+
+				Expect(errorList).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("#ValidateNetworkServerReachability", func() {
+		It("should require dns and ntp to be reachable when the seed does not deploy their default policies", func() {
+			controlPlaneConfig := &apismetal.ControlPlaneConfig{
+				NetworkAccessType: new(apismetal.NetworkAccessBaseline),
+			}
+
+			errorList := ValidateNetworkServerReachability(controlPlaneConfig, &apismetal.Partition{}, []string{apismetal.DefaultNetworkPolicyAllowToHTTPS}, field.NewPath("test"))
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("test.networkPolicies"),
+					"Detail": Equal("dns servers must be reachable through a network policy on port 53 when allow-to-dns is not deployed"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeRequired),
+					"Field":  Equal("test.networkPolicies"),
+					"Detail": Equal("ntp servers must be reachable through a network policy on port 123 when allow-to-ntp is not deployed"),
+				})),
+			))
 		})
 	})
 })
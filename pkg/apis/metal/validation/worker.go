@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/metal-go/api/models"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	// reservedMachineTagKeys are the keys of the tags which are set on the machines by the worker controller
+	reservedMachineTagKeys = sets.NewString(
+		"kubernetes.io/cluster",
+		"kubernetes.io/role",
+		"node.kubernetes.io/instance-type",
+		"topology.kubernetes.io/region",
+		"topology.kubernetes.io/zone",
+	)
+	reservedMachineTagPrefix = "cluster.metal-stack.io/"
+)
+
+// ValidateWorkerConfig validates the provider config of a worker pool.
+func ValidateWorkerConfig(workerConfig *apismetal.WorkerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateUniqueNonEmptyStrings(workerConfig.AdditionalNetworks, fldPath.Child("additionalNetworks"))...)
+	allErrs = append(allErrs, validateUniqueNonEmptyStrings(workerConfig.Tags, fldPath.Child("tags"))...)
+	allErrs = append(allErrs, validateUniqueNonEmptyStrings(workerConfig.PlacementTags, fldPath.Child("placementTags"))...)
+
+	for i, t := range workerConfig.Tags {
+		key, _, _ := strings.Cut(t, "=")
+		if reservedMachineTagKeys.Has(key) || strings.HasPrefix(key, reservedMachineTagPrefix) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("tags").Index(i), "tag is reserved for the machines of the cluster"))
+		}
+	}
+
+	if workerConfig.ImageID != nil && *workerConfig.ImageID == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("imageID"), *workerConfig.ImageID, "image id must not be empty"))
+	}
+
+	return allErrs
+}
+
+// ValidateWorkerConfigAgainstNetworks validates the additional networks of a worker pool against the networks of the
+// metal-api. The machines of clusters with a restricted or forbidden network access type reach their additional
+// networks without passing the firewall, so the prefixes and destinations of these networks must be contained in the
+// allowed or approved egress networks of the cluster.
+func ValidateWorkerConfigAgainstNetworks(workerConfig *apismetal.WorkerConfig, controlPlaneConfig *apismetal.ControlPlaneConfig, partition *apismetal.Partition, networks map[string]*models.V1NetworkResponse, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var allowedEgress []netip.Prefix
+	isolated := controlPlaneConfig.NetworkAccessType != nil && *controlPlaneConfig.NetworkAccessType != apismetal.NetworkAccessBaseline
+	if isolated && partition.NetworkIsolation != nil {
+		egress := partition.NetworkIsolation.AllowedNetworks.Egress
+		if controlPlaneConfig.AdditionalAllowedNetworks != nil {
+			egress = append(slices.Clone(egress), controlPlaneConfig.AdditionalAllowedNetworks.Egress...)
+		}
+		allowedEgress = parseMaskedPrefixes(egress)
+	}
+
+	for i, networkID := range workerConfig.AdditionalNetworks {
+		fp := fldPath.Child("additionalNetworks").Index(i)
+
+		nw, ok := networks[networkID]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(fp, networkID))
+			continue
+		}
+
+		if pointer.SafeDeref(nw.Privatesuper) || pointer.SafeDeref(nw.Underlay) {
+			allErrs = append(allErrs, field.Invalid(fp, networkID, "additional network must not be a private super or underlay network"))
+			continue
+		}
+
+		if !isolated || partition.NetworkIsolation == nil {
+			// a missing network isolation of the partition is reported by the validation of the network access type
+			continue
+		}
+
+		for _, cidr := range slices.Concat(nw.Prefixes, nw.Destinationprefixes) {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil || !prefixContainedInAny(prefix.Masked(), allowedEgress) {
+				allErrs = append(allErrs, field.Forbidden(fp, fmt.Sprintf("network %q of the additional network is not contained in the allowed or approved egress networks of the cluster", cidr)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateUniqueNonEmptyStrings(values []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, v := range values {
+		if v == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "must not be empty"))
+			continue
+		}
+		if seen.Has(v) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), v))
+		}
+		seen.Insert(v)
+	}
+
+	return allErrs
+}
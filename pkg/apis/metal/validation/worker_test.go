@@ -0,0 +1,179 @@
+package validation_test
+
+import (
+	"github.com/metal-stack/metal-go/api/models"
+
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	. "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Worker validation", func() {
+	Describe("#ValidateWorkerConfig", func() {
+		var (
+			workerConfig *apismetal.WorkerConfig
+			path         *field.Path
+		)
+
+		BeforeEach(func() {
+			workerConfig = &apismetal.WorkerConfig{
+				AdditionalNetworks: []string{"storage"},
+				Tags:               []string{"team=ci"},
+				PlacementTags:      []string{"ci"},
+				ImageID:            new("ubuntu-24.4-20250101"),
+			}
+			path = field.NewPath("workers").Index(0).Child("providerConfig")
+		})
+
+		It("should pass a valid worker config", func() {
+			Expect(ValidateWorkerConfig(workerConfig, path)).To(BeEmpty())
+		})
+
+		It("should pass an empty worker config", func() {
+			Expect(ValidateWorkerConfig(&apismetal.WorkerConfig{}, path)).To(BeEmpty())
+		})
+
+		It("should prevent empty and duplicate entries", func() {
+			workerConfig.AdditionalNetworks = []string{"storage", "storage"}
+			workerConfig.PlacementTags = []string{""}
+
+			errorList := ValidateWorkerConfig(workerConfig, path)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeDuplicate),
+					"Field":    Equal("workers[0].providerConfig.additionalNetworks[1]"),
+					"BadValue": Equal("storage"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("workers[0].providerConfig.placementTags[0]"),
+				})),
+			))
+		})
+
+		It("should prevent tags which are reserved for the cluster", func() {
+			workerConfig.Tags = []string{"cluster.metal-stack.io/id=abc", "topology.kubernetes.io/zone=a", "team=ci"}
+
+			errorList := ValidateWorkerConfig(workerConfig, path)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("workers[0].providerConfig.tags[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("workers[0].providerConfig.tags[1]"),
+				})),
+			))
+		})
+
+		It("should prevent an empty image id", func() {
+			workerConfig.ImageID = new("")
+
+			errorList := ValidateWorkerConfig(workerConfig, path)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("workers[0].providerConfig.imageID"),
+			}))))
+		})
+	})
+
+	Describe("#ValidateWorkerConfigAgainstNetworks", func() {
+		var (
+			workerConfig       *apismetal.WorkerConfig
+			controlPlaneConfig *apismetal.ControlPlaneConfig
+			partition          *apismetal.Partition
+			networks           map[string]*models.V1NetworkResponse
+			path               *field.Path
+		)
+
+		BeforeEach(func() {
+			workerConfig = &apismetal.WorkerConfig{
+				AdditionalNetworks: []string{"storage", "mpls"},
+			}
+			controlPlaneConfig = &apismetal.ControlPlaneConfig{}
+			partition = &apismetal.Partition{
+				NetworkIsolation: &apismetal.NetworkIsolation{
+					AllowedNetworks: apismetal.AllowedNetworks{
+						Egress: []string{"10.128.0.0/16"},
+					},
+					ApprovableNetworks: &apismetal.AllowedNetworks{
+						Egress: []string{"100.127.0.0/16"},
+					},
+				},
+			}
+			networks = map[string]*models.V1NetworkResponse{
+				"storage": {
+					ID:       new("storage"),
+					Prefixes: []string{"10.128.0.0/22"},
+				},
+				"mpls": {
+					ID:                  new("mpls"),
+					Prefixes:            []string{"100.127.1.0/24"},
+					Destinationprefixes: []string{"100.127.0.0/16"},
+				},
+				"tenant-super": {
+					ID:           new("tenant-super"),
+					Privatesuper: new(true),
+				},
+			}
+			path = field.NewPath("workers").Index(0).Child("providerConfig")
+		})
+
+		It("should pass any network of baseline shoots", func() {
+			Expect(ValidateWorkerConfigAgainstNetworks(workerConfig, controlPlaneConfig, partition, networks, path)).To(BeEmpty())
+		})
+
+		It("should prevent unknown and private super networks", func() {
+			workerConfig.AdditionalNetworks = []string{"unknown", "tenant-super"}
+
+			errorList := ValidateWorkerConfigAgainstNetworks(workerConfig, controlPlaneConfig, partition, networks, path)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotFound),
+					"Field": Equal("workers[0].providerConfig.additionalNetworks[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("workers[0].providerConfig.additionalNetworks[1]"),
+				})),
+			))
+		})
+
+		It("should forbid networks which are neither allowed nor approved for isolated shoots", func() {
+			controlPlaneConfig.NetworkAccessType = new(apismetal.NetworkAccessRestricted)
+
+			errorList := ValidateWorkerConfigAgainstNetworks(workerConfig, controlPlaneConfig, partition, networks, path)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("workers[0].providerConfig.additionalNetworks[1]"),
+					"Detail": ContainSubstring(`"100.127.1.0/24"`),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("workers[0].providerConfig.additionalNetworks[1]"),
+					"Detail": ContainSubstring(`"100.127.0.0/16"`),
+				})),
+			))
+		})
+
+		It("should pass approved networks for isolated shoots", func() {
+			controlPlaneConfig.NetworkAccessType = new(apismetal.NetworkAccessForbidden)
+			controlPlaneConfig.AdditionalAllowedNetworks = &apismetal.AllowedNetworks{
+				Egress: []string{"100.127.0.0/16"},
+			}
+
+			Expect(ValidateWorkerConfigAgainstNetworks(workerConfig, controlPlaneConfig, partition, networks, path)).To(BeEmpty())
+		})
+	})
+})
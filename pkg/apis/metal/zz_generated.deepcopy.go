@@ -120,6 +120,28 @@ func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 		*out = new(NetworkAccessType)
 		**out = **in
 	}
+	if in.StaticIPReservations != nil {
+		in, out := &in.StaticIPReservations, &out.StaticIPReservations
+		*out = make([]StaticIPReservation, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalAllowedNetworks != nil {
+		in, out := &in.AdditionalAllowedNetworks, &out.AdditionalAllowedNetworks
+		*out = new(AllowedNetworks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]NetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DisabledDefaultNetworkPolicies != nil {
+		in, out := &in.DisabledDefaultNetworkPolicies, &out.DisabledDefaultNetworkPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -283,6 +305,32 @@ func (in *FirewallControllerVersion) DeepCopy() *FirewallControllerVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallDefaulting) DeepCopyInto(out *FirewallDefaulting) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageOS != nil {
+		in, out := &in.ImageOS, &out.ImageOS
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallDefaulting.
+func (in *FirewallDefaulting) DeepCopy() *FirewallDefaulting {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallDefaulting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FirewallStatus) DeepCopyInto(out *FirewallStatus) {
 	*out = *in
@@ -360,6 +408,11 @@ func (in *InfrastructureStatus) DeepCopyInto(out *InfrastructureStatus) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.Firewall = in.Firewall
+	if in.StaticIPs != nil {
+		in, out := &in.StaticIPs, &out.StaticIPs
+		*out = make([]StaticIPStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -412,6 +465,13 @@ func (in *MetalControlPlane) DeepCopyInto(out *MetalControlPlane) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FirewallImageClassifications != nil {
+		in, out := &in.FirewallImageClassifications, &out.FirewallImageClassifications
+		*out = make(map[string]VersionClassification, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.FirewallControllerVersions != nil {
 		in, out := &in.FirewallControllerVersions, &out.FirewallControllerVersions
 		*out = make([]FirewallControllerVersion, len(*in))
@@ -420,6 +480,16 @@ func (in *MetalControlPlane) DeepCopyInto(out *MetalControlPlane) {
 		}
 	}
 	out.NftablesExporter = in.NftablesExporter
+	if in.ValidateNetworks != nil {
+		in, out := &in.ValidateNetworks, &out.ValidateNetworks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidateCredentials != nil {
+		in, out := &in.ValidateCredentials, &out.ValidateCredentials
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -433,18 +503,65 @@ func (in *MetalControlPlane) DeepCopy() *MetalControlPlane {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAccessMigration) DeepCopyInto(out *NetworkAccessMigration) {
+	*out = *in
+	if in.UncoveredImages != nil {
+		in, out := &in.UncoveredImages, &out.UncoveredImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAccessMigration.
+func (in *NetworkAccessMigration) DeepCopy() *NetworkAccessMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAccessMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAccessStatus) DeepCopyInto(out *NetworkAccessStatus) {
+	*out = *in
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(NetworkAccessMigration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAccessStatus.
+func (in *NetworkAccessStatus) DeepCopy() *NetworkAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkIsolation) DeepCopyInto(out *NetworkIsolation) {
 	*out = *in
 	in.AllowedNetworks.DeepCopyInto(&out.AllowedNetworks)
+	if in.ApprovableNetworks != nil {
+		in, out := &in.ApprovableNetworks, &out.ApprovableNetworks
+		*out = new(AllowedNetworks)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DNSServers != nil {
 		in, out := &in.DNSServers, &out.DNSServers
-		*out = make([]string, len(*in))
+		*out = make([]NetworkServer, len(*in))
 		copy(*out, *in)
 	}
 	if in.NTPServers != nil {
 		in, out := &in.NTPServers, &out.NTPServers
-		*out = make([]string, len(*in))
+		*out = make([]NetworkServer, len(*in))
 		copy(*out, *in)
 	}
 	if in.RegistryMirrors != nil {
@@ -454,6 +571,11 @@ func (in *NetworkIsolation) DeepCopyInto(out *NetworkIsolation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DefaultNetworkPolicies != nil {
+		in, out := &in.DefaultNetworkPolicies, &out.DefaultNetworkPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -467,6 +589,94 @@ func (in *NetworkIsolation) DeepCopy() *NetworkIsolation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]NetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]NetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyPort) DeepCopyInto(out *NetworkPolicyPort) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPort.
+func (in *NetworkPolicyPort) DeepCopy() *NetworkPolicyPort {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyRule) DeepCopyInto(out *NetworkPolicyRule) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]NetworkPolicyPort, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRule.
+func (in *NetworkPolicyRule) DeepCopy() *NetworkPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkServer) DeepCopyInto(out *NetworkServer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkServer.
+func (in *NetworkServer) DeepCopy() *NetworkServer {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NftablesExporter) DeepCopyInto(out *NftablesExporter) {
 	*out = *in
@@ -496,6 +706,21 @@ func (in *Partition) DeepCopyInto(out *Partition) {
 		*out = new(NetworkIsolation)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxRateLimit != nil {
+		in, out := &in.MaxRateLimit, &out.MaxRateLimit
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.FirewallDefaulting != nil {
+		in, out := &in.FirewallDefaulting, &out.FirewallDefaulting
+		*out = new(FirewallDefaulting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultExternalNetworks != nil {
+		in, out := &in.DefaultExternalNetworks, &out.DefaultExternalNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -546,6 +771,83 @@ func (in *RegistryMirror) DeepCopy() *RegistryMirror {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticIPReservation) DeepCopyInto(out *StaticIPReservation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticIPReservation.
+func (in *StaticIPReservation) DeepCopy() *StaticIPReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticIPReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticIPStatus) DeepCopyInto(out *StaticIPStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticIPStatus.
+func (in *StaticIPStatus) DeepCopy() *StaticIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.AdditionalNetworks != nil {
+		in, out := &in.AdditionalNetworks, &out.AdditionalNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlacementTags != nil {
+		in, out := &in.PlacementTags, &out.PlacementTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageID != nil {
+		in, out := &in.ImageID, &out.ImageID
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerConfig.
+func (in *WorkerConfig) DeepCopy() *WorkerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerStatus) DeepCopyInto(out *WorkerStatus) {
 	*out = *in
@@ -555,6 +857,11 @@ func (in *WorkerStatus) DeepCopyInto(out *WorkerStatus) {
 		*out = make([]MachineImage, len(*in))
 		copy(*out, *in)
 	}
+	if in.NetworkAccess != nil {
+		in, out := &in.NetworkAccess, &out.NetworkAccess
+		*out = new(NetworkAccessStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
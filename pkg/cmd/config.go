@@ -6,6 +6,7 @@ import (
 	healthcheckconfig "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
 	configloader "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/loader"
+	configvalidation "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config/validation"
 
 	"github.com/spf13/pflag"
 )
@@ -38,6 +39,10 @@ func (c *ConfigOptions) Complete() error {
 		return err
 	}
 
+	if errList := configvalidation.ValidateControllerConfiguration(config); len(errList) != 0 {
+		return errList.ToAggregate()
+	}
+
 	c.config = &Config{config}
 	return nil
 }
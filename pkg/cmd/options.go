@@ -11,6 +11,7 @@ import (
 	extensioncontrolplanewebhook "github.com/gardener/gardener/extensions/pkg/webhook/controlplane"
 	extensionshootwebhook "github.com/gardener/gardener/extensions/pkg/webhook/shoot"
 	controlplanecontroller "github.com/metal-stack/gardener-extension-provider-metal/pkg/controller/controlplane"
+	credentialsrotationcontroller "github.com/metal-stack/gardener-extension-provider-metal/pkg/controller/credentialsrotation"
 	healthcheckcontroller "github.com/metal-stack/gardener-extension-provider-metal/pkg/controller/healthcheck"
 	infrastructurecontroller "github.com/metal-stack/gardener-extension-provider-metal/pkg/controller/infrastructure"
 	workercontroller "github.com/metal-stack/gardener-extension-provider-metal/pkg/controller/worker"
@@ -27,6 +28,7 @@ func ControllerSwitchOptions() *controllercmd.SwitchOptions {
 		controllercmd.Switch(extensionscontrolplanecontroller.ControllerName, controlplanecontroller.AddToManager),
 		controllercmd.Switch(extensionsworkercontroller.ControllerName, workercontroller.AddToManager),
 		controllercmd.Switch(extensionsheartbeatcontroller.ControllerName, extensionsheartbeatcontroller.AddToManager),
+		controllercmd.Switch(credentialsrotationcontroller.ControllerName, credentialsrotationcontroller.AddToManager),
 	)
 }
 
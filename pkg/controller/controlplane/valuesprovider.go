@@ -30,6 +30,7 @@ import (
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	metalvalidation "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
 
 	metalgo "github.com/metal-stack/metal-go"
 
@@ -50,6 +51,7 @@ import (
 
 	extensionssecretsmanager "github.com/gardener/gardener/extensions/pkg/util/secret/manager"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils/chart"
 	"github.com/gardener/gardener/pkg/utils/secrets"
@@ -61,6 +63,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -336,6 +340,10 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 	checksums map[string]string,
 	scaledDown bool,
 ) (map[string]any, error) {
+	if v1beta1helper.IsWorkerless(cluster.Shoot) {
+		return vp.getWorkerlessControlPlaneChartValues(cluster), nil
+	}
+
 	infrastructureConfig := &apismetal.InfrastructureConfig{}
 	if _, _, err := vp.decoder.Decode(cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw, nil, infrastructureConfig); err != nil {
 		return nil, fmt.Errorf("could not decode providerConfig of infrastructure %w", err)
@@ -351,11 +359,17 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		return nil, err
 	}
 
-	metalControlPlane, _, err := helper.FindMetalControlPlane(cloudProfileConfig, infrastructureConfig.PartitionID)
+	metalControlPlane, partition, err := helper.FindMetalControlPlane(cloudProfileConfig, infrastructureConfig.PartitionID)
 	if err != nil {
 		return nil, err
 	}
 
+	// the seed defaults are only known here and may change after the shoot was admitted, the reconciliation is not
+	// blocked by them as the shoot components are deployed regardless
+	if errList := metalvalidation.ValidateNetworkServerReachability(cpConfig, partition, vp.seedDefaultNetworkPolicies(), field.NewPath("spec", "providerConfig")); len(errList) != 0 {
+		vp.logger.Error(errList.ToAggregate(), "the default network policies of the seed are not compatible with the control plane config, dns or ntp servers may be unreachable from the cluster", "namespace", cp.Namespace)
+	}
+
 	metalCredentials, err := metalclient.ReadCredentialsFromSecretRef(ctx, vp.client, &cp.Spec.SecretRef)
 	if err != nil {
 		return nil, err
@@ -385,6 +399,10 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		return nil, err
 	}
 
+	if err := vp.ensureStaticIPsReconciled(ctx, cpConfig, infrastructure); err != nil {
+		return nil, err
+	}
+
 	sshSecret, err := helper.GetLatestSSHSecret(ctx, vp.client, cp.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("could not find current ssh secret: %w", err)
@@ -395,7 +413,12 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		return nil, fmt.Errorf("could not get ca from secret: %w", err)
 	}
 
-	ccmValues, err := getCCMChartValues(ctx, sshSecret, cpConfig, infrastructureConfig, infrastructure, cluster, checksums, scaledDown, mclient, metalControlPlane, nws, secretsReader)
+	networkAccessType, err := vp.appliedNetworkAccessType(ctx, cluster, cpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ccmValues, err := getCCMChartValues(ctx, sshSecret, cpConfig, networkAccessType, infrastructureConfig, infrastructure, cluster, checksums, scaledDown, mclient, metalControlPlane, nws, secretsReader)
 	if err != nil {
 		return nil, err
 	}
@@ -417,8 +440,82 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 			"checksum/secret-cloudprovider":                                    checksums[v1beta1constants.SecretNameCloudProvider],
 		},
 		"genericTokenKubeconfigSecretName": extensionscontroller.GenericTokenKubeconfigSecretNameFromCluster(cluster),
+		"firewallMonitoring": map[string]any{
+			"enabled": vp.firewallMonitoringEnabled(),
+		},
+		"workloadIdentity": map[string]any{
+			"enabled": metalCredentials.WorkloadIdentity,
+		},
 	}
 
+	merge(values, ccmValues, storageValues, firewallValues)
+
+	vp.setCommonControlPlaneChartValues(values)
+
+	return values, nil
+}
+
+// ensureStaticIPsReconciled triggers a reconciliation of the infrastructure if the static ip reservations of the
+// control plane config differ from the reserved ips in the infrastructure status. the reservations are part of the
+// control plane config, so a change of them does not change the infrastructure resource on its own.
+func (vp *valuesProvider) ensureStaticIPsReconciled(ctx context.Context, cpConfig *apismetal.ControlPlaneConfig, infrastructure *extensionsv1alpha1.Infrastructure) error {
+	infrastructureStatus, err := helper.InfrastructureStatusFromInfrastructure(infrastructure)
+	if err != nil {
+		return fmt.Errorf("could not decode infrastructure status %w", err)
+	}
+
+	wanted := sets.New[string]()
+	for _, r := range cpConfig.StaticIPReservations {
+		wanted.Insert(r.Name + "/" + r.Network)
+	}
+
+	reserved := sets.New[string]()
+	for _, ip := range infrastructureStatus.StaticIPs {
+		reserved.Insert(ip.Name + "/" + ip.Network)
+	}
+
+	if wanted.Equal(reserved) || infrastructure.Annotations[v1beta1constants.GardenerOperation] == v1beta1constants.GardenerOperationReconcile {
+		return nil
+	}
+
+	vp.logger.Info("static ip reservations changed, triggering reconciliation of the infrastructure", "namespace", infrastructure.Namespace)
+
+	patch := client.MergeFrom(infrastructure.DeepCopy())
+	metav1.SetMetaDataAnnotation(&infrastructure.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)
+
+	return vp.client.Patch(ctx, infrastructure, patch)
+}
+
+// getWorkerlessControlPlaneChartValues returns the values for the control plane chart of a shoot without workers.
+// Such a shoot has neither a private network nor a firewall, so none of the controllers managing them are deployed.
+func (vp *valuesProvider) getWorkerlessControlPlaneChartValues(cluster *extensionscontroller.Cluster) map[string]any {
+	values := map[string]any{
+		"imagePullPolicy":                  helper.ImagePullPolicyFromString(vp.controllerConfig.ImagePullPolicy),
+		"genericTokenKubeconfigSecretName": extensionscontroller.GenericTokenKubeconfigSecretNameFromCluster(cluster),
+		"cloudControllerManager": map[string]any{
+			"enabled": false,
+		},
+		"firewallControllerManager": map[string]any{
+			"enabled": false,
+		},
+		"duros": map[string]any{
+			"enabled": false,
+		},
+		"firewallMonitoring": map[string]any{
+			"enabled": false,
+		},
+		"workloadIdentity": map[string]any{
+			"enabled": false,
+		},
+	}
+
+	vp.setCommonControlPlaneChartValues(values)
+
+	return values
+}
+
+// setCommonControlPlaneChartValues sets the control plane chart values which do not depend on the shoot.
+func (vp *valuesProvider) setCommonControlPlaneChartValues(values map[string]any) {
 	if vp.controllerConfig.NetworkPolicies != nil {
 		var ingressValues map[string]any
 
@@ -435,13 +532,9 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		}
 	}
 
-	merge(values, ccmValues, storageValues, firewallValues)
-
 	if vp.controllerConfig.ImagePullSecret != nil {
 		values["imagePullSecret"] = vp.controllerConfig.ImagePullSecret.DockerConfigJSON
 	}
-
-	return values, nil
 }
 
 // merge all source maps in the target map
@@ -464,6 +557,10 @@ func (vp *valuesProvider) GetControlPlaneExposureChartValues(
 
 // GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by the generic actuator.
 func (vp *valuesProvider) GetControlPlaneShootChartValues(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster, secretsReader secretsmanager.Reader, checksums map[string]string) (map[string]any, error) {
+	if v1beta1helper.IsWorkerless(cluster.Shoot) {
+		return vp.getWorkerlessControlPlaneShootChartValues(), nil
+	}
+
 	infrastructureConfig := &apismetal.InfrastructureConfig{}
 	if _, _, err := vp.decoder.Decode(cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw, nil, infrastructureConfig); err != nil {
 		return nil, fmt.Errorf("could not decode providerConfig of infrastructure %w", err)
@@ -517,6 +614,34 @@ func (vp *valuesProvider) GetControlPlaneShootChartValues(ctx context.Context, c
 	return values, nil
 }
 
+// getWorkerlessControlPlaneShootChartValues returns the values for the shoot control plane chart of a shoot without
+// workers. All components which require nodes, a private network or a firewall are disabled.
+func (vp *valuesProvider) getWorkerlessControlPlaneShootChartValues() map[string]any {
+	return map[string]any{
+		"imagePullPolicy": helper.ImagePullPolicyFromString(vp.controllerConfig.ImagePullPolicy),
+		"apiserverIPs":    []string{},
+		"duros": map[string]any{
+			"enabled": false,
+		},
+		"cilium": map[string]any{
+			"enabled": false,
+		},
+		"metallb": map[string]any{
+			"enabled": false,
+		},
+		"nodeInit": map[string]any{
+			"enabled": false,
+		},
+		"firewall": map[string]any{
+			"enabled": false,
+		},
+		"defaultNetworkPolicies": []string{},
+		"firewallMonitoring": map[string]any{
+			"enabled": false,
+		},
+	}
+}
+
 // getControlPlaneShootChartValues returns the values for the shoot control plane chart.
 func (vp *valuesProvider) getControlPlaneShootChartValues(ctx context.Context, cpConfig *apismetal.ControlPlaneConfig, cluster *extensionscontroller.Cluster, partition *apismetal.Partition, nws networkMap, infrastructure *extensionsv1alpha1.Infrastructure, infrastructureConfig *apismetal.InfrastructureConfig, secretsReader secretsmanager.Reader, checksums map[string]string) (map[string]any, error) {
 	namespace := cluster.ObjectMeta.Name
@@ -577,58 +702,40 @@ func (vp *valuesProvider) getControlPlaneShootChartValues(ctx context.Context, c
 		}
 	}
 
-	networkAccessType := apismetal.NetworkAccessBaseline
-	if cpConfig.NetworkAccessType != nil {
-		networkAccessType = *cpConfig.NetworkAccessType
+	networkAccessType, err := vp.appliedNetworkAccessType(ctx, cluster, cpConfig)
+	if err != nil {
+		return nil, err
 	}
 	restrictedOrForbidden := networkAccessType != apismetal.NetworkAccessBaseline
 	if restrictedOrForbidden && partition.NetworkIsolation == nil {
 		return nil, fmt.Errorf("cluster-isolation is not supported in partition %q", infrastructureConfig.PartitionID)
 	}
 
-	var dnsCidrs []string
+	var dnsServers []map[string]any
 	if restrictedOrForbidden && partition.NetworkIsolation != nil {
-		dnsCidrs = make([]string, len(partition.NetworkIsolation.DNSServers))
-		for i, ip := range partition.NetworkIsolation.DNSServers {
-			parsedIP, err := netip.ParseAddr(ip)
-			if err != nil {
-				return nil, fmt.Errorf("unable to parse dns ip:%w", err)
-			}
-			if parsedIP.Is4() {
-				dnsCidrs[i] = ip + ipv4HostMask
-			}
-			if parsedIP.Is6() {
-				dnsCidrs[i] = ip + ipv6HostMask
-			}
+		dnsServers, err = networkServersToValues(partition.NetworkIsolation.DNSServers, helper.DefaultDNSPort, apismetal.NetworkServerProtocolUDP, apismetal.NetworkServerProtocolTCP)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse dns servers:%w", err)
 		}
 	}
 	if !restrictedOrForbidden {
-		dnsCidrs = []string{ipv4Any}
+		dnsServers = []map[string]any{anyNetworkServerValues(helper.DefaultDNSPort, apismetal.NetworkServerProtocolUDP, apismetal.NetworkServerProtocolTCP)}
 	}
-	if len(dnsCidrs) == 0 {
+	if len(dnsServers) == 0 {
 		return nil, fmt.Errorf("no dns configured")
 	}
 
-	var ntpCidrs []string
+	var ntpServers []map[string]any
 	if restrictedOrForbidden && partition.NetworkIsolation != nil {
-		ntpCidrs = make([]string, len(partition.NetworkIsolation.NTPServers))
-		for i, ip := range partition.NetworkIsolation.NTPServers {
-			parsedIP, err := netip.ParseAddr(ip)
-			if err != nil {
-				return nil, fmt.Errorf("unable to parse ntp ip:%w", err)
-			}
-			if parsedIP.Is4() {
-				ntpCidrs[i] = ip + ipv4HostMask
-			}
-			if parsedIP.Is6() {
-				ntpCidrs[i] = ip + ipv6HostMask
-			}
+		ntpServers, err = networkServersToValues(partition.NetworkIsolation.NTPServers, helper.DefaultNTPPort, apismetal.NetworkServerProtocolUDP)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ntp servers:%w", err)
 		}
 	}
 	if !restrictedOrForbidden {
-		ntpCidrs = []string{ipv4Any}
+		ntpServers = []map[string]any{anyNetworkServerValues(helper.DefaultNTPPort, apismetal.NetworkServerProtocolUDP)}
 	}
-	if len(ntpCidrs) == 0 {
+	if len(ntpServers) == 0 {
 		return nil, fmt.Errorf("no ntp configured")
 	}
 
@@ -643,6 +750,11 @@ func (vp *valuesProvider) getControlPlaneShootChartValues(ctx context.Context, c
 		}
 	}
 
+	var additionalEgressNetworks []string
+	if restrictedOrForbidden && cpConfig.AdditionalAllowedNetworks != nil {
+		additionalEgressNetworks = cpConfig.AdditionalAllowedNetworks.Egress
+	}
+
 	values := map[string]any{
 		"imagePullPolicy": helper.ImagePullPolicyFromString(vp.controllerConfig.ImagePullPolicy),
 		"apiserverIPs":    apiserverIPs,
@@ -651,11 +763,20 @@ func (vp *valuesProvider) getControlPlaneShootChartValues(ctx context.Context, c
 		"cilium":          ciliumValues,
 		"metallb":         metallbValues,
 		"nodeInit":        nodeInitValues,
+		"firewall": map[string]any{
+			"enabled": true,
+		},
 		"networkAccess": map[string]any{
 			"restrictedOrForbidden": restrictedOrForbidden,
-			"dnsCidrs":              dnsCidrs,
-			"ntpCidrs":              ntpCidrs,
+			"dnsServers":            dnsServers,
+			"ntpServers":            ntpServers,
 			"registryMirrors":       networkAccessMirrors,
+			"additionalEgress":      additionalEgressNetworks,
+		},
+		"networkPolicies":        networkPoliciesToValues(cpConfig.NetworkPolicies),
+		"defaultNetworkPolicies": helper.DefaultNetworkPolicies(vp.seedDefaultNetworkPolicies(), partition, cpConfig),
+		"firewallMonitoring": map[string]any{
+			"enabled": vp.firewallMonitoringEnabled(),
 		},
 	}
 
@@ -698,6 +819,39 @@ func (vp *valuesProvider) getControlPlaneShootChartValues(ctx context.Context, c
 	return values, nil
 }
 
+// appliedNetworkAccessType returns the network access type which is applied to the firewall of the shoot. while a
+// migration to a stricter type is pending, the shoot components keep the configuration of the applied type, otherwise
+// they would expect a firewall which is not deployed yet.
+func (vp *valuesProvider) appliedNetworkAccessType(ctx context.Context, cluster *extensionscontroller.Cluster, cpConfig *apismetal.ControlPlaneConfig) (apismetal.NetworkAccessType, error) {
+	desired := apismetal.NetworkAccessBaseline
+	if cpConfig.NetworkAccessType != nil {
+		desired = *cpConfig.NetworkAccessType
+	}
+
+	worker := &extensionsv1alpha1.Worker{}
+	if err := vp.client.Get(ctx, client.ObjectKey{Namespace: cluster.ObjectMeta.Name, Name: cluster.Shoot.Name}, worker); err != nil {
+		if apierrors.IsNotFound(err) {
+			return desired, nil
+		}
+		return "", fmt.Errorf("unable to get worker: %w", err)
+	}
+
+	return helper.AppliedNetworkAccessType(worker, desired)
+}
+
+// seedDefaultNetworkPolicies returns the default cluster-wide network policies configured for the seed.
+func (vp *valuesProvider) seedDefaultNetworkPolicies() []string {
+	if vp.controllerConfig.NetworkPolicies == nil {
+		return nil
+	}
+	return vp.controllerConfig.NetworkPolicies.DefaultClusterwideNetworkPolicies
+}
+
+// firewallMonitoringEnabled returns true if the firewall metrics are collected by the shoot prometheus of the seed.
+func (vp *valuesProvider) firewallMonitoringEnabled() bool {
+	return vp.controllerConfig.FirewallMonitoring != nil && vp.controllerConfig.FirewallMonitoring.Enabled
+}
+
 // getSecret returns the secret with the given namespace/secretName
 func (vp *valuesProvider) getSecret(ctx context.Context, namespace string, secretName string) (*corev1.Secret, error) {
 	key := client.ObjectKey{Namespace: namespace, Name: secretName}
@@ -716,6 +870,14 @@ func (vp *valuesProvider) getSecret(ctx context.Context, namespace string, secre
 
 // GetStorageClassesChartValues returns the values for the storage classes chart applied by the generic actuator.
 func (vp *valuesProvider) GetStorageClassesChartValues(_ context.Context, controlPlane *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) (map[string]any, error) {
+	if v1beta1helper.IsWorkerless(cluster.Shoot) {
+		// csi-lvm provisions volumes on the local disks of the nodes
+		return map[string]any{
+			"isDefaultStorageClass": false,
+			"disableCsiLvm":         true,
+		}, nil
+	}
+
 	cp, err := helper.ControlPlaneConfigFromControlPlane(controlPlane)
 	if err != nil {
 		return nil, err
@@ -744,6 +906,7 @@ func getCCMChartValues(
 	ctx context.Context,
 	sshSecret *corev1.Secret,
 	cpConfig *apismetal.ControlPlaneConfig,
+	networkAccessType apismetal.NetworkAccessType,
 	infrastructureConfig *apismetal.InfrastructureConfig,
 	infrastructure *extensionsv1alpha1.Infrastructure,
 	cluster *extensionscontroller.Cluster,
@@ -766,11 +929,21 @@ func getCCMChartValues(
 		return nil, err
 	}
 
-	defaultExternalNetwork, err := getDefaultExternalNetwork(nws, cpConfig, infrastructureConfig)
+	defaultExternalNetwork, err := getDefaultExternalNetwork(nws, cpConfig, networkAccessType, infrastructureConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	infrastructureStatus, err := helper.InfrastructureStatusFromInfrastructure(infrastructure)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode infrastructure status %w", err)
+	}
+
+	var staticIPs []string
+	for _, ip := range infrastructureStatus.StaticIPs {
+		staticIPs = append(staticIPs, ip.IP)
+	}
+
 	serverSecret, found := secretsReader.Get(metal.CloudControllerManagerServerName)
 	if !found {
 		return nil, fmt.Errorf("secret %q not found", metal.CloudControllerManagerServerName)
@@ -783,6 +956,7 @@ func getCCMChartValues(
 
 	values := map[string]any{
 		"cloudControllerManager": map[string]any{
+			"enabled":                true,
 			"replicas":               extensionscontroller.GetControlPlaneReplicas(cluster, scaledDown, 1),
 			"projectID":              projectID,
 			"clusterID":              cluster.Shoot.UID,
@@ -790,6 +964,7 @@ func getCCMChartValues(
 			"networkID":              *privateNetwork.ID,
 			"defaultExternalNetwork": defaultExternalNetwork,
 			"additionalNetworks":     strings.Join(infrastructureConfig.Firewall.Networks, ","),
+			"staticIPs":              strings.Join(staticIPs, ","),
 			"loadBalancer":           loadBalancer,
 			"sshPublicKey":           string(sshSecret.Data["id_rsa.pub"]),
 			"metal": map[string]any{
@@ -996,6 +1171,7 @@ func (vp *valuesProvider) getFirewallControllerManagerChartValues(ctx context.Co
 		// when woken up, a new firewall is created with new token, ssh key etc.
 		// This will break the firewall-only case actually only used in our test env.
 		// TODO: deletion of the firewall is not yet implemented.
+		"enabled":          true,
 		"replicas":         extensionscontroller.GetReplicas(cluster, 1),
 		"clusterID":        string(cluster.Shoot.GetUID()),
 		"seedApiURL":       seedApiURL,
@@ -1095,75 +1271,96 @@ func registryMirrorToValueMap(r apismetal.RegistryMirror) (map[string]any, error
 	}, nil
 }
 
-func getDefaultExternalNetwork(nws networkMap, cpConfig *apismetal.ControlPlaneConfig, infrastructureConfig *apismetal.InfrastructureConfig) (string, error) {
-	if cpConfig.CloudControllerManager != nil && cpConfig.CloudControllerManager.DefaultExternalNetwork != nil {
-		// user has set a specific default external network, check if it's valid
+// ShootChartImageNames returns the names of the images which are deployed into the shoot and run on its nodes.
+func ShootChartImageNames() []string {
+	return slices.Clone(cpShootChart.Images)
+}
 
-		networkID := *cpConfig.CloudControllerManager.DefaultExternalNetwork
+func networkServersToValues(servers []apismetal.NetworkServer, defaultPort int32, defaultProtocols ...apismetal.NetworkServerProtocol) ([]map[string]any, error) {
+	var result []map[string]any
 
-		if !slices.Contains(infrastructureConfig.Firewall.Networks, networkID) {
-			return "", fmt.Errorf("given default external network not contained in firewall networks")
+	for _, s := range servers {
+		parsedIP, err := netip.ParseAddr(s.IP)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ip of server %q:%w", s.IP, err)
+		}
+		cidr := parsedIP.String()
+		if parsedIP.Is4() {
+			cidr = cidr + ipv4HostMask
+		}
+		if parsedIP.Is6() {
+			cidr = cidr + ipv6HostMask
 		}
 
-		return networkID, nil
+		result = append(result, map[string]any{
+			"cidr":      cidr,
+			"port":      defaultPort,
+			"protocols": helper.NetworkServerProtocols(s, defaultProtocols...),
+		})
 	}
 
-	if pointer.SafeDeref(cpConfig.NetworkAccessType) == apismetal.NetworkAccessForbidden {
-		// for isolated clusters with forbidden access type it makes no sense to define a default external network because connections will not be allowed automatically anyway
-		return "", nil
+	return result, nil
+}
+
+func anyNetworkServerValues(port int32, protocols ...apismetal.NetworkServerProtocol) map[string]any {
+	return map[string]any{
+		"cidr":      ipv4Any,
+		"port":      port,
+		"protocols": protocols,
 	}
+}
 
-	var (
-		externalNetworks []*models.V1NetworkResponse
-		dmzNetworks      []*models.V1NetworkResponse // dmzNetworks are deprecated, this can be removed after all users had enough time to migrate to isolated clusters
-	)
+func networkPoliciesToValues(policies []apismetal.NetworkPolicy) []map[string]any {
+	rulesToValues := func(rules []apismetal.NetworkPolicyRule) []map[string]any {
+		var result []map[string]any
+		for _, rule := range rules {
+			var ports []map[string]any
+			for _, port := range rule.Ports {
+				ports = append(ports, map[string]any{
+					"protocol": port.Protocol,
+					"port":     port.Port,
+				})
+			}
 
-	for _, networkID := range infrastructureConfig.Firewall.Networks {
-		nw, ok := nws[networkID]
-		if !ok {
-			return "", fmt.Errorf("network defined in firewall networks does not exist in metal-api")
+			result = append(result, map[string]any{
+				"cidrs": rule.CIDRs,
+				"ports": ports,
+			})
 		}
+		return result
+	}
 
-		_, ok = nw.Labels[tag.NetworkDefaultExternal]
-		if !ok {
-			continue
-		}
+	var result []map[string]any
+	for _, p := range policies {
+		result = append(result, map[string]any{
+			"name":    p.Name,
+			"egress":  rulesToValues(p.Egress),
+			"ingress": rulesToValues(p.Ingress),
+		})
+	}
 
-		if nw.Parentnetworkid == "" {
-			externalNetworks = append(externalNetworks, nw)
-			continue
-		}
+	return result
+}
 
-		parent, ok := nws[nw.Parentnetworkid]
-		if !ok {
-			return "", fmt.Errorf("network defined in firewall networks specified a parent network that does not exist in metal-api")
-		}
+func getDefaultExternalNetwork(nws networkMap, cpConfig *apismetal.ControlPlaneConfig, networkAccessType apismetal.NetworkAccessType, infrastructureConfig *apismetal.InfrastructureConfig) (string, error) {
+	if cpConfig.CloudControllerManager != nil && cpConfig.CloudControllerManager.DefaultExternalNetwork != nil {
+		// user has set a specific default external network, check if it's valid
 
-		if *parent.Privatesuper {
-			dmzNetworks = append(dmzNetworks, nw)
-			continue
-		}
-	}
+		networkID := *cpConfig.CloudControllerManager.DefaultExternalNetwork
 
-	// if there is an external network we prefer this over DMZ networks
-	// from the external network we prefer the one that is the default
-	// if there are multiple external networks it's impossible to distinguish which one to choose, so we use the first one defined in the list
-	if len(externalNetworks) != 0 {
-		for _, nw := range externalNetworks {
-			if _, ok := nw.Labels[tag.NetworkDefault]; ok {
-				return *nw.ID, nil
-			}
+		if !slices.Contains(infrastructureConfig.Firewall.Networks, networkID) {
+			return "", fmt.Errorf("given default external network not contained in firewall networks")
 		}
 
-		return *externalNetworks[0].ID, nil
+		return networkID, nil
 	}
 
-	if len(dmzNetworks) != 0 {
-		// if there are multiple dmz networks it's impossible to distinguish which one to choose, so we use the first one defined in the list
-		return *dmzNetworks[0].ID, nil
+	if networkAccessType == apismetal.NetworkAccessForbidden {
+		// for isolated clusters with forbidden access type it makes no sense to define a default external network because connections will not be allowed automatically anyway
+		return "", nil
 	}
 
-	return "", nil
+	return metalclient.DefaultExternalNetwork(nws, infrastructureConfig.Firewall.Networks)
 }
 
 func setDurosDefaultStorageClass(scs []map[string]any, cpConfig *apismetal.ControlPlaneConfig) []map[string]any {
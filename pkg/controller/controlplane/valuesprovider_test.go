@@ -1,18 +1,28 @@
 package controlplane
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"slices"
 	"testing"
 	"time"
 
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-openapi/strfmt"
 	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/metal-go/api/models"
 	"github.com/metal-stack/metal-lib/pkg/tag"
 	"github.com/metal-stack/metal-lib/pkg/testcommon"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_firewallCompareFunc(t *testing.T) {
@@ -130,6 +140,125 @@ func Test_registryMirrorToValueMap(t *testing.T) {
 	}
 }
 
+func Test_networkServersToValues(t *testing.T) {
+	tests := []struct {
+		name             string
+		servers          []apismetal.NetworkServer
+		defaultPort      int32
+		defaultProtocols []apismetal.NetworkServerProtocol
+		want             []map[string]any
+		wantErr          bool
+	}{
+		{
+			name:             "servers without port and protocol use the defaults",
+			servers:          []apismetal.NetworkServer{{IP: "1.1.1.1"}, {IP: "2001:db8::1"}},
+			defaultPort:      53,
+			defaultProtocols: []apismetal.NetworkServerProtocol{apismetal.NetworkServerProtocolUDP, apismetal.NetworkServerProtocolTCP},
+			want: []map[string]any{
+				{
+					"cidr":      "1.1.1.1/32",
+					"port":      int32(53),
+					"protocols": []apismetal.NetworkServerProtocol{apismetal.NetworkServerProtocolUDP, apismetal.NetworkServerProtocolTCP},
+				},
+				{
+					"cidr":      "2001:db8::1/128",
+					"port":      int32(53),
+					"protocols": []apismetal.NetworkServerProtocol{apismetal.NetworkServerProtocolUDP, apismetal.NetworkServerProtocolTCP},
+				},
+			},
+		},
+		{
+			name:             "servers with protocol",
+			servers:          []apismetal.NetworkServer{{IP: "1.1.1.1", Protocol: apismetal.NetworkServerProtocolTCP}},
+			defaultPort:      53,
+			defaultProtocols: []apismetal.NetworkServerProtocol{apismetal.NetworkServerProtocolUDP, apismetal.NetworkServerProtocolTCP},
+			want: []map[string]any{
+				{
+					"cidr":      "1.1.1.1/32",
+					"port":      int32(53),
+					"protocols": []apismetal.NetworkServerProtocol{apismetal.NetworkServerProtocolTCP},
+				},
+			},
+		},
+		{
+			name:        "invalid ip",
+			servers:     []apismetal.NetworkServer{{IP: "1.1.1"}},
+			defaultPort: 123,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := networkServersToValues(tt.servers, tt.defaultPort, tt.defaultProtocols...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("networkServersToValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("networkServersToValues() diff = %s", diff)
+			}
+		})
+	}
+}
+
+func Test_networkPoliciesToValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []apismetal.NetworkPolicy
+		want     []map[string]any
+	}{
+		{
+			name:     "no policies",
+			policies: nil,
+			want:     nil,
+		},
+		{
+			name: "egress and ingress rules",
+			policies: []apismetal.NetworkPolicy{
+				{
+					Name: "allow-to-backends",
+					Egress: []apismetal.NetworkPolicyRule{
+						{
+							CIDRs: []string{"10.0.0.0/24"},
+							Ports: []apismetal.NetworkPolicyPort{{Protocol: corev1.ProtocolTCP, Port: 5432}},
+						},
+					},
+					Ingress: []apismetal.NetworkPolicyRule{
+						{
+							CIDRs: []string{"10.1.0.0/24"},
+						},
+					},
+				},
+			},
+			want: []map[string]any{
+				{
+					"name": "allow-to-backends",
+					"egress": []map[string]any{
+						{
+							"cidrs": []string{"10.0.0.0/24"},
+							"ports": []map[string]any{{"protocol": corev1.ProtocolTCP, "port": int32(5432)}},
+						},
+					},
+					"ingress": []map[string]any{
+						{
+							"cidrs": []string{"10.1.0.0/24"},
+							"ports": []map[string]any(nil),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := networkPoliciesToValues(tt.policies)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("networkPoliciesToValues() diff = %s", diff)
+			}
+		})
+	}
+}
+
 func Test_getDefaultExternalNetwork(t *testing.T) {
 	var (
 		internetFirewall = &apismetal.InfrastructureConfig{
@@ -201,6 +330,7 @@ func Test_getDefaultExternalNetwork(t *testing.T) {
 		name                 string
 		nws                  networkMap
 		cpConfig             *apismetal.ControlPlaneConfig
+		networkAccessType    apismetal.NetworkAccessType
 		infrastructureConfig *apismetal.InfrastructureConfig
 		want                 string
 		wantErr              error
@@ -241,10 +371,26 @@ func Test_getDefaultExternalNetwork(t *testing.T) {
 			cpConfig:             &apismetal.ControlPlaneConfig{},
 			want:                 "dmz-network",
 		},
+		{
+			name:                 "no default external network with forbidden network access",
+			nws:                  nws,
+			infrastructureConfig: internetFirewall,
+			cpConfig:             &apismetal.ControlPlaneConfig{NetworkAccessType: new(apismetal.NetworkAccessForbidden)},
+			networkAccessType:    apismetal.NetworkAccessForbidden,
+			want:                 "",
+		},
+		{
+			name:                 "default external network is kept while the migration to forbidden is pending",
+			nws:                  nws,
+			infrastructureConfig: internetFirewall,
+			cpConfig:             &apismetal.ControlPlaneConfig{NetworkAccessType: new(apismetal.NetworkAccessForbidden)},
+			networkAccessType:    apismetal.NetworkAccessRestricted,
+			want:                 "internet",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getDefaultExternalNetwork(tt.nws, tt.cpConfig, tt.infrastructureConfig)
+			got, err := getDefaultExternalNetwork(tt.nws, tt.cpConfig, tt.networkAccessType, tt.infrastructureConfig)
 			if diff := cmp.Diff(tt.wantErr, err, testcommon.ErrorStringComparer()); diff != "" {
 				t.Errorf("error diff (+got -want):\n %s", diff)
 			}
@@ -256,6 +402,81 @@ func Test_getDefaultExternalNetwork(t *testing.T) {
 	}
 }
 
+func Test_appliedNetworkAccessType(t *testing.T) {
+	const namespace = "shoot--project--name"
+
+	var (
+		cluster = &extensionscontroller.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Shoot:      &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "name"}},
+		}
+		worker = func(providerStatus string) *extensionsv1alpha1.Worker {
+			w := &extensionsv1alpha1.Worker{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "name"},
+			}
+			if providerStatus != "" {
+				w.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(providerStatus)}
+			}
+			return w
+		}
+	)
+
+	tests := []struct {
+		name     string
+		worker   *extensionsv1alpha1.Worker
+		cpConfig *apismetal.ControlPlaneConfig
+		want     apismetal.NetworkAccessType
+	}{
+		{
+			name:     "desired type without worker",
+			cpConfig: &apismetal.ControlPlaneConfig{NetworkAccessType: new(apismetal.NetworkAccessRestricted)},
+			want:     apismetal.NetworkAccessRestricted,
+		},
+		{
+			name:     "baseline without network access type",
+			worker:   worker(""),
+			cpConfig: &apismetal.ControlPlaneConfig{},
+			want:     apismetal.NetworkAccessBaseline,
+		},
+		{
+			name:     "desired type without network access status",
+			worker:   worker(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerStatus"}`),
+			cpConfig: &apismetal.ControlPlaneConfig{NetworkAccessType: new(apismetal.NetworkAccessForbidden)},
+			want:     apismetal.NetworkAccessForbidden,
+		},
+		{
+			name:     "applied type while a migration is pending",
+			worker:   worker(`{"apiVersion":"metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerStatus","networkAccess":{"type":"restricted","migration":{"target":"forbidden","message":"1 images are not served by a registry mirror of the partition"}}}`),
+			cpConfig: &apismetal.ControlPlaneConfig{NetworkAccessType: new(apismetal.NetworkAccessForbidden)},
+			want:     apismetal.NetworkAccessRestricted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := extensionsv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			builder := fakeclient.NewClientBuilder().WithScheme(scheme)
+			if tt.worker != nil {
+				builder = builder.WithObjects(tt.worker)
+			}
+
+			vp := &valuesProvider{client: builder.Build()}
+
+			got, err := vp.appliedNetworkAccessType(context.Background(), cluster, tt.cpConfig)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
+
 func Test_setDurosDefaultStorageClass(t *testing.T) {
 	tests := []struct {
 		name string
@@ -418,3 +639,81 @@ func Test_setDurosDefaultStorageClass(t *testing.T) {
 		})
 	}
 }
+
+func Test_getWorkerlessControlPlaneChartValues(t *testing.T) {
+	cluster := &extensionscontroller.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				v1beta1constants.AnnotationKeyGenericTokenKubeconfigSecretName: "generic-token-kubeconfig-abcde",
+			},
+		},
+	}
+
+	disabled := map[string]any{
+		"enabled": false,
+	}
+
+	tests := []struct {
+		name             string
+		controllerConfig config.ControllerConfiguration
+		want             map[string]any
+	}{
+		{
+			name:             "default controller config",
+			controllerConfig: config.ControllerConfiguration{},
+			want: map[string]any{
+				"imagePullPolicy":                  corev1.PullIfNotPresent,
+				"genericTokenKubeconfigSecretName": "generic-token-kubeconfig-abcde",
+				"cloudControllerManager":           disabled,
+				"firewallControllerManager":        disabled,
+				"duros":                            disabled,
+				"firewallMonitoring":               disabled,
+				"workloadIdentity":                 disabled,
+			},
+		},
+		{
+			name: "network policies and image pull secret are passed through",
+			controllerConfig: config.ControllerConfiguration{
+				ImagePullPolicy: "Always",
+				ImagePullSecret: &config.ImagePullSecret{
+					DockerConfigJSON: "e30=",
+				},
+				NetworkPolicies: &config.NetworkPolicies{
+					IngressController: &config.NetpolsIngressController{
+						Namespace:   "ingress-nginx",
+						PodSelector: map[string]string{"app": "ingress-nginx"},
+					},
+				},
+			},
+			want: map[string]any{
+				"imagePullPolicy":                  corev1.PullAlways,
+				"genericTokenKubeconfigSecretName": "generic-token-kubeconfig-abcde",
+				"cloudControllerManager":           disabled,
+				"firewallControllerManager":        disabled,
+				"duros":                            disabled,
+				"firewallMonitoring":               disabled,
+				"workloadIdentity":                 disabled,
+				"imagePullSecret":                  "e30=",
+				"networkPolicies": map[string]any{
+					"enabled": true,
+					"ingressController": map[string]any{
+						"namespace":   "ingress-nginx",
+						"podSelector": map[string]string{"app": "ingress-nginx"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := &valuesProvider{
+				controllerConfig: tt.controllerConfig,
+			}
+
+			got := vp.getWorkerlessControlPlaneChartValues(cluster)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
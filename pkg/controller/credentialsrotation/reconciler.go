@@ -0,0 +1,258 @@
+package credentialsrotation
+
+import (
+	"context"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	metalclient "github.com/metal-stack/gardener-extension-provider-metal/pkg/metal/client"
+)
+
+const (
+	// CredentialsChecksumAnnotation is put on the cloudprovider secret with the checksum of the credentials which
+	// were rolled out last.
+	CredentialsChecksumAnnotation = "metal.provider.extensions.gardener.cloud/credentials-checksum"
+
+	// ConditionTypeCredentialsRotation is the type of the condition in the control plane status which reports
+	// the progress of a rotation of the metal-api credentials.
+	ConditionTypeCredentialsRotation gardencorev1beta1.ConditionType = "CredentialsRotation"
+
+	reasonCredentialsInvalid     = "CredentialsInvalid"
+	reasonRotationProgressing    = "CredentialsRotationProgressing"
+	reasonRotationCompleted      = "CredentialsRotationCompleted"
+	completionCheckRequeuePeriod = 30 * time.Second
+)
+
+type reconciler struct {
+	logger logr.Logger
+	client client.Client
+	// secrets reads the cloudprovider secrets from the cache of the controller.
+	secrets client.Reader
+	clock   clock.Clock
+}
+
+// Reconcile rolls out rotated metal-api credentials of a cloudprovider secret. the new credentials are verified against
+// the metal-api first, then the control plane, the infrastructure and the worker are reconciled, such that all
+// components pick up the new credentials.
+//
+// the verification is advisory only: invalid credentials are reported in the condition of the control plane and are
+// not rolled out by this controller, but gardener still passes them to the components with the next reconciliation
+// of the shoot, as the previous credentials are not retained anywhere.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	secret := &corev1.Secret{}
+	if err := r.secrets.Get(ctx, req.NamespacedName, secret); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, r.client, secret.Namespace)
+	if err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if cluster.Shoot == nil || cluster.Shoot.Spec.Provider.Type != metal.Type {
+		return reconcile.Result{}, nil
+	}
+
+	cp := &extensionsv1alpha1.ControlPlane{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: secret.Namespace, Name: cluster.Shoot.Name}, cp); err != nil {
+		// the control plane is reconciled with the current credentials once it is created
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	checksum := credentialsChecksum(secret)
+
+	rolledOut, ok := secret.Annotations[CredentialsChecksumAnnotation]
+	if !ok || extensionscontroller.IsHibernated(cluster) {
+		// nothing to roll out, all components are reconciled with the current credentials on creation and wake up
+		return reconcile.Result{}, r.annotateChecksum(ctx, secret, checksum)
+	}
+
+	if rolledOut == checksum {
+		return r.checkCompletion(ctx, cp)
+	}
+
+	log := r.logger.WithValues("namespace", secret.Namespace)
+
+	if metal.IsWorkloadIdentitySecret(secret) {
+		// tokens of workload identities are refreshed regularly by gardener and do not need to be verified. the
+		// cloud-controller-manager and the firewall-controller-manager read the token from their environment, so the
+		// control plane is reconciled to roll them through the checksum of the cloudprovider secret in their pod
+		// templates. all other components read the token on every reconciliation.
+		log.Info("rolling out refreshed workload identity token")
+
+		if err := r.triggerReconcile(ctx, client.ObjectKeyFromObject(cp), cp); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{}, r.annotateChecksum(ctx, secret, checksum)
+	}
+
+	if err := r.verifyCredentials(ctx, cluster, secret); err != nil {
+		log.Error(err, "rotated credentials are invalid, not triggering a roll out")
+		return reconcile.Result{}, r.updateCondition(ctx, cp, gardencorev1beta1.ConditionFalse, reasonCredentialsInvalid, err.Error())
+	}
+
+	log.Info("rolling out rotated credentials")
+
+	for _, obj := range []client.Object{cp, &extensionsv1alpha1.Infrastructure{}, &extensionsv1alpha1.Worker{}} {
+		if err := r.triggerReconcile(ctx, client.ObjectKey{Namespace: secret.Namespace, Name: cluster.Shoot.Name}, obj); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := r.updateCondition(ctx, cp, gardencorev1beta1.ConditionProgressing, reasonRotationProgressing, "the control plane, the infrastructure and the worker are reconciled with the rotated credentials"); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.annotateChecksum(ctx, secret, checksum); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: completionCheckRequeuePeriod}, nil
+}
+
+// credentialsChecksum returns the checksum of the credentials contained in the given secret. other data of the secret is
+// not considered, such that only changes of the credentials are rolled out.
+func credentialsChecksum(secret *corev1.Secret) string {
+	keys := []string{metal.APIKey, metal.APIHMac}
+	if metal.IsWorkloadIdentitySecret(secret) {
+		keys = []string{securityv1alpha1constants.DataKeyToken}
+	}
+
+	credentials := map[string][]byte{}
+	for _, key := range keys {
+		if value, ok := secret.Data[key]; ok {
+			credentials[key] = value
+		}
+	}
+
+	return utils.ComputeSecretChecksum(credentials)
+}
+
+func (r *reconciler) verifyCredentials(ctx context.Context, cluster *extensionscontroller.Cluster, secret *corev1.Secret) error {
+	infrastructureConfig, err := helper.InfrastructureConfigFromClusterShootSpec(cluster)
+	if err != nil {
+		return err
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	metalControlPlane, _, err := helper.FindMetalControlPlane(cloudProfileConfig, infrastructureConfig.PartitionID)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := metal.ReadCredentialsSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	mclient, err := metalclient.NewClientFromCredentials(metalControlPlane.Endpoint, credentials)
+	if err != nil {
+		return err
+	}
+
+	return metalclient.VerifyCredentials(ctx, mclient, infrastructureConfig.ProjectID)
+}
+
+// triggerReconcile annotates the given extension resource with the reconcile operation, resources which do not
+// exist (e.g. the worker of a workerless shoot) are skipped.
+func (r *reconciler) triggerReconcile(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if err := r.client.Get(ctx, key, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1beta1constants.GardenerOperation] = v1beta1constants.GardenerOperationReconcile
+	obj.SetAnnotations(annotations)
+
+	return r.client.Patch(ctx, obj, patch)
+}
+
+// checkCompletion reports the completion of a rotation as soon as the control plane, the infrastructure and the
+// worker were reconciled successfully after the rotation was rolled out.
+func (r *reconciler) checkCompletion(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) (reconcile.Result, error) {
+	condition := v1beta1helper.GetCondition(cp.Status.Conditions, ConditionTypeCredentialsRotation)
+	if condition == nil || condition.Status != gardencorev1beta1.ConditionProgressing {
+		return reconcile.Result{}, nil
+	}
+
+	for _, obj := range []extensionsv1alpha1.Object{cp, &extensionsv1alpha1.Infrastructure{}, &extensionsv1alpha1.Worker{}} {
+		if obj != cp {
+			if err := r.client.Get(ctx, client.ObjectKeyFromObject(cp), obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return reconcile.Result{}, err
+			}
+		}
+
+		if !reconciledSince(obj, condition.LastTransitionTime) {
+			return reconcile.Result{RequeueAfter: completionCheckRequeuePeriod}, nil
+		}
+	}
+
+	return reconcile.Result{}, r.updateCondition(ctx, cp, gardencorev1beta1.ConditionTrue, reasonRotationCompleted, "all components were reconciled with the rotated credentials")
+}
+
+// reconciledSince returns true if the given extension resource was reconciled successfully after the given time.
+func reconciledSince(obj extensionsv1alpha1.Object, since metav1.Time) bool {
+	if obj.GetAnnotations()[v1beta1constants.GardenerOperation] == v1beta1constants.GardenerOperationReconcile {
+		return false
+	}
+
+	lastOperation := obj.GetExtensionStatus().GetLastOperation()
+	if lastOperation == nil {
+		return false
+	}
+
+	return lastOperation.State == gardencorev1beta1.LastOperationStateSucceeded && !lastOperation.LastUpdateTime.Before(&since)
+}
+
+func (r *reconciler) updateCondition(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, status gardencorev1beta1.ConditionStatus, reason, message string) error {
+	patch := client.MergeFrom(cp.DeepCopy())
+
+	condition := v1beta1helper.GetOrInitConditionWithClock(r.clock, cp.Status.Conditions, ConditionTypeCredentialsRotation)
+	condition = v1beta1helper.UpdatedConditionWithClock(r.clock, condition, status, reason, message)
+	if status == gardencorev1beta1.ConditionProgressing {
+		// every roll out restarts the progress, also if a previous roll out has not completed yet
+		condition.LastTransitionTime = metav1.NewTime(r.clock.Now())
+	}
+	cp.Status.Conditions = v1beta1helper.MergeConditions(cp.Status.Conditions, condition)
+
+	return r.client.Status().Patch(ctx, cp, patch)
+}
+
+func (r *reconciler) annotateChecksum(ctx context.Context, secret *corev1.Secret, checksum string) error {
+	if secret.Annotations[CredentialsChecksumAnnotation] == checksum {
+		return nil
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, CredentialsChecksumAnnotation, checksum)
+
+	return r.client.Patch(ctx, secret, patch)
+}
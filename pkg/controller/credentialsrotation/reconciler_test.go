@@ -0,0 +1,203 @@
+package credentialsrotation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+)
+
+func TestReconcile(t *testing.T) {
+	const namespace = "shoot--project--name"
+
+	var (
+		staticSecret = func(data map[string][]byte) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider},
+				Data:       data,
+			}
+		}
+		tokenSecret = func(token string) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      v1beta1constants.SecretNameCloudProvider,
+					Labels:    map[string]string{securityv1alpha1constants.LabelPurpose: securityv1alpha1constants.LabelPurposeWorkloadIdentityTokenRequestor},
+				},
+				Data: map[string][]byte{securityv1alpha1constants.DataKeyToken: []byte(token)},
+			}
+		}
+		annotated = func(secret *corev1.Secret, checksum string) *corev1.Secret {
+			secret.Annotations = map[string]string{CredentialsChecksumAnnotation: checksum}
+			return secret
+		}
+	)
+
+	tests := []struct {
+		name             string
+		secret           *corev1.Secret
+		wantChecksum     string
+		wantCPReconciled bool
+	}{
+		{
+			name:         "checksum is annotated on first reconciliation",
+			secret:       staticSecret(map[string][]byte{metal.APIHMac: []byte("hmac")}),
+			wantChecksum: credentialsChecksum(staticSecret(map[string][]byte{metal.APIHMac: []byte("hmac")})),
+		},
+		{
+			name: "unchanged credentials are skipped",
+			secret: annotated(
+				staticSecret(map[string][]byte{metal.APIHMac: []byte("hmac"), "other": []byte("changed")}),
+				credentialsChecksum(staticSecret(map[string][]byte{metal.APIHMac: []byte("hmac")})),
+			),
+			wantChecksum: credentialsChecksum(staticSecret(map[string][]byte{metal.APIHMac: []byte("hmac")})),
+		},
+		{
+			name:             "refreshed workload identity token triggers a reconciliation of the control plane",
+			secret:           annotated(tokenSecret("token-2"), credentialsChecksum(tokenSecret("token-1"))),
+			wantChecksum:     credentialsChecksum(tokenSecret("token-2")),
+			wantCPReconciled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := corev1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := extensionsv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+				tt.secret,
+				&extensionsv1alpha1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: namespace},
+					Spec: extensionsv1alpha1.ClusterSpec{
+						Shoot: runtime.RawExtension{Raw: []byte(`{"metadata":{"name":"name"},"spec":{"provider":{"type":"metal"}}}`)},
+					},
+				},
+				&extensionsv1alpha1.ControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "name"},
+				},
+			).Build()
+
+			r := &reconciler{
+				logger:  logr.Discard(),
+				client:  c,
+				secrets: c,
+				clock:   clocktesting.NewFakeClock(time.Now()),
+			}
+
+			ctx := context.Background()
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(tt.secret), secret); err != nil {
+				t.Fatal(err)
+			}
+			if got := secret.Annotations[CredentialsChecksumAnnotation]; got != tt.wantChecksum {
+				t.Errorf("checksum annotation = %q, want %q", got, tt.wantChecksum)
+			}
+
+			cp := &extensionsv1alpha1.ControlPlane{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "name"}, cp); err != nil {
+				t.Fatal(err)
+			}
+			if got := cp.Annotations[v1beta1constants.GardenerOperation] == v1beta1constants.GardenerOperationReconcile; got != tt.wantCPReconciled {
+				t.Errorf("control plane reconciled = %v, want %v", got, tt.wantCPReconciled)
+			}
+		})
+	}
+}
+
+func Test_reconciledSince(t *testing.T) {
+	var (
+		rolledOut = metav1.NewTime(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+		before    = metav1.NewTime(rolledOut.Add(-time.Minute))
+		after     = metav1.NewTime(rolledOut.Add(time.Minute))
+	)
+
+	tests := []struct {
+		name string
+		obj  *extensionsv1alpha1.ControlPlane
+		want bool
+	}{
+		{
+			name: "not yet reconciled",
+			obj:  &extensionsv1alpha1.ControlPlane{},
+			want: false,
+		},
+		{
+			name: "operation annotation not yet removed",
+			obj: &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"gardener.cloud/operation": "reconcile"},
+				},
+				Status: extensionsv1alpha1.ControlPlaneStatus{
+					DefaultStatus: extensionsv1alpha1.DefaultStatus{
+						LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded, LastUpdateTime: after},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "reconciled before the roll out",
+			obj: &extensionsv1alpha1.ControlPlane{
+				Status: extensionsv1alpha1.ControlPlaneStatus{
+					DefaultStatus: extensionsv1alpha1.DefaultStatus{
+						LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded, LastUpdateTime: before},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "reconcile failed after the roll out",
+			obj: &extensionsv1alpha1.ControlPlane{
+				Status: extensionsv1alpha1.ControlPlaneStatus{
+					DefaultStatus: extensionsv1alpha1.DefaultStatus{
+						LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateError, LastUpdateTime: after},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "reconciled after the roll out",
+			obj: &extensionsv1alpha1.ControlPlane{
+				Status: extensionsv1alpha1.ControlPlaneStatus{
+					DefaultStatus: extensionsv1alpha1.DefaultStatus{
+						LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded, LastUpdateTime: after},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reconciledSince(tt.obj, rolledOut); got != tt.want {
+				t.Errorf("reconciledSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	healthcheckconfig "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
 	"github.com/metal-stack/metal-lib/pkg/pointer"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck/worker"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,15 +46,35 @@ type AddOptions struct {
 
 // RegisterHealthChecks registers health checks for each extension resource
 func RegisterHealthChecks(ctx context.Context, mgr manager.Manager, opts AddOptions) error {
-	durosPreCheck := func(_ context.Context, _ client.Client, _ client.Object, _ any) bool {
-		return opts.ControllerConfig.Storage.Duros.Enabled
-	}
-	metallbPreCheck := func(_ context.Context, _ client.Client, _ client.Object, obj any) bool {
+	// workerless shoots have neither a firewall nor nodes, so all components requiring them are not deployed
+	workerPreCheck := func(_ context.Context, _ client.Client, _ client.Object, obj any) bool {
 		cluster, ok := obj.(*extensionscontroller.Cluster)
 		if !ok || cluster == nil {
 			return false
 		}
-		return pointer.SafeDeref(cluster.Shoot.Spec.Networking.Type) == "calico"
+		return !v1beta1helper.IsWorkerless(cluster.Shoot)
+	}
+	durosPreCheck := func(ctx context.Context, c client.Client, o client.Object, obj any) bool {
+		return opts.ControllerConfig.Storage.Duros.Enabled && workerPreCheck(ctx, c, o, obj)
+	}
+	metallbPreCheck := func(ctx context.Context, c client.Client, o client.Object, obj any) bool {
+		if !workerPreCheck(ctx, c, o, obj) {
+			return false
+		}
+		cluster := obj.(*extensionscontroller.Cluster)
+		return pointer.SafeDeref(pointer.SafeDeref(cluster.Shoot.Spec.Networking).Type) == "calico"
+	}
+	// only the nodes of isolated clusters pull their images through the registry mirrors of the partition
+	isolatedPreCheck := func(ctx context.Context, c client.Client, o client.Object, obj any) bool {
+		if !workerPreCheck(ctx, c, o, obj) {
+			return false
+		}
+		cpConfig, err := helper.ControlPlaneConfigFromClusterShootSpec(obj.(*extensionscontroller.Cluster))
+		if err != nil {
+			return false
+		}
+		networkAccessType := pointer.SafeDeref(cpConfig.NetworkAccessType)
+		return networkAccessType != "" && networkAccessType != apismetal.NetworkAccessBaseline
 	}
 
 	if err := healthcheck.DefaultRegistration(
@@ -66,6 +89,7 @@ func RegisterHealthChecks(ctx context.Context, mgr manager.Manager, opts AddOpti
 			{
 				ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
 				HealthCheck:   general.NewSeedDeploymentHealthChecker(metal.CloudControllerManagerDeploymentName),
+				PreCheckFunc:  workerPreCheck,
 			},
 			{
 				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
@@ -83,12 +107,18 @@ func RegisterHealthChecks(ctx context.Context, mgr manager.Manager, opts AddOpti
 			{
 				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
 				HealthCheck:   CheckFirewall(),
+				PreCheckFunc:  workerPreCheck,
 			},
 			{
 				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
 				HealthCheck:   CheckMetalLB(),
 				PreCheckFunc:  metallbPreCheck,
 			},
+			{
+				ConditionType: ConditionTypeRegistryMirrorCoverage,
+				HealthCheck:   CheckRegistryMirrorCoverage(),
+				PreCheckFunc:  isolatedPreCheck,
+			},
 		},
 		// TODO(acumino): Remove this condition in a future release.
 		sets.New(gardencorev1beta1.ShootSystemComponentsHealthy),
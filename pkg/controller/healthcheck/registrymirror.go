@@ -0,0 +1,120 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imagevectorutils "github.com/gardener/gardener/pkg/utils/imagevector"
+
+	"github.com/go-logr/logr"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/controller/controlplane"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/imagevector"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ConditionTypeRegistryMirrorCoverage is the type of the condition in the control plane status which reports whether
+// the images deployed into an isolated cluster are served by the registry mirrors of the partition.
+const ConditionTypeRegistryMirrorCoverage = "RegistryMirrorCoverage"
+
+// RegistryMirrorHealthChecker contains all the information for the registry mirror coverage HealthCheck
+type RegistryMirrorHealthChecker struct {
+	logger     logr.Logger
+	seedClient client.Client
+}
+
+// CheckRegistryMirrorCoverage is a healthCheck function to check whether the images running on the nodes of an isolated
+// cluster are served by the registry mirrors of the partition. images of the seed components are not pulled through
+// the firewall of the cluster, so they are not considered.
+func CheckRegistryMirrorCoverage() healthcheck.HealthCheck {
+	return &RegistryMirrorHealthChecker{}
+}
+
+// InjectSourceClient injects the seed client
+func (healthChecker *RegistryMirrorHealthChecker) InjectSourceClient(sourceClient client.Client) {
+	healthChecker.seedClient = sourceClient
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *RegistryMirrorHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-registry-mirror", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *RegistryMirrorHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *healthChecker
+	return &copy
+}
+
+// Check executes the health check
+func (healthChecker *RegistryMirrorHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	cluster, err := extensionscontroller.GetCluster(ctx, healthChecker.seedClient, request.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get cluster %q: %w", request.Namespace, err)
+	}
+
+	infrastructureConfig, err := helper.InfrastructureConfigFromClusterShootSpec(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	_, partition, err := helper.FindMetalControlPlane(cloudProfileConfig, infrastructureConfig.PartitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := registryMirrorCoverage(imagevector.ImageVector(), controlplane.ShootChartImageNames(), infrastructureConfig.PartitionID, partition.NetworkIsolation)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Status != gardencorev1beta1.ConditionTrue {
+		healthChecker.logger.Info("Health check failed", "namespace", request.Namespace, "detail", result.Detail)
+	}
+
+	return result, nil
+}
+
+// registryMirrorCoverage resolves the given image names from the image vector and reports the images whose registry is
+// not served by any of the registry mirrors of the partition.
+func registryMirrorCoverage(iv imagevectorutils.ImageVector, names []string, partitionID string, networkIsolation *apismetal.NetworkIsolation) (*healthcheck.SingleCheckResult, error) {
+	if networkIsolation == nil {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionFalse,
+			Detail: fmt.Sprintf("partition %q has no network isolation, so no registry mirrors serve the images of the cluster", partitionID),
+		}, nil
+	}
+
+	var images []string
+	for _, name := range names {
+		image, err := iv.FindImage(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve image %q: %w", name, err)
+		}
+		images = append(images, image.String())
+	}
+
+	if uncovered := helper.UncoveredImages(networkIsolation.RegistryMirrors, images); len(uncovered) > 0 {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionFalse,
+			Detail: fmt.Sprintf("the following images are not served by a registry mirror of partition %q: %s", partitionID, strings.Join(uncovered, ", ")),
+		}, nil
+	}
+
+	return &healthcheck.SingleCheckResult{
+		Status: gardencorev1beta1.ConditionTrue,
+	}, nil
+}
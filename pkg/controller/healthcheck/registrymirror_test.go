@@ -0,0 +1,85 @@
+package healthcheck
+
+import (
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/google/go-cmp/cmp"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+)
+
+func Test_registryMirrorCoverage(t *testing.T) {
+	iv, err := imagevector.Read([]byte(`images:
+- name: metallb-speaker
+  repository: quay.io/metallb/speaker
+  tag: "v0.15.2"
+- name: node-init
+  repository: ghcr.io/metal-stack/node-init
+  tag: "v0.1.6"
+`))
+	if err != nil {
+		t.Fatalf("unable to read image vector: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		names            []string
+		networkIsolation *apismetal.NetworkIsolation
+		wantStatus       gardencorev1beta1.ConditionStatus
+		wantDetail       string
+		wantErr          bool
+	}{
+		{
+			name:  "all images covered",
+			names: []string{"metallb-speaker", "node-init"},
+			networkIsolation: &apismetal.NetworkIsolation{
+				RegistryMirrors: []apismetal.RegistryMirror{
+					{Endpoint: "https://r.metal-stack.dev", MirrorOf: []string{"ghcr.io", "quay.io"}},
+				},
+			},
+			wantStatus: gardencorev1beta1.ConditionTrue,
+		},
+		{
+			name:  "uncovered images are reported",
+			names: []string{"metallb-speaker", "node-init"},
+			networkIsolation: &apismetal.NetworkIsolation{
+				RegistryMirrors: []apismetal.RegistryMirror{
+					{Endpoint: "https://r.metal-stack.dev", MirrorOf: []string{"ghcr.io"}},
+				},
+			},
+			wantStatus: gardencorev1beta1.ConditionFalse,
+			wantDetail: `the following images are not served by a registry mirror of partition "a": quay.io/metallb/speaker:v0.15.2`,
+		},
+		{
+			name:       "partition without network isolation is not covered",
+			names:      []string{"metallb-speaker"},
+			wantStatus: gardencorev1beta1.ConditionFalse,
+			wantDetail: `partition "a" has no network isolation, so no registry mirrors serve the images of the cluster`,
+		},
+		{
+			name:             "unknown image",
+			names:            []string{"unknown"},
+			networkIsolation: &apismetal.NetworkIsolation{},
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registryMirrorCoverage(iv, tt.names, "a", tt.networkIsolation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("registryMirrorCoverage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("registryMirrorCoverage() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if diff := cmp.Diff(tt.wantDetail, got.Detail); diff != "" {
+				t.Errorf("registryMirrorCoverage() detail diff = %s", diff)
+			}
+		})
+	}
+}
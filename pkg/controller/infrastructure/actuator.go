@@ -53,16 +53,18 @@ func decodeInfrastructure(infrastructure *extensionsv1alpha1.Infrastructure, dec
 }
 
 func updateProviderStatus(ctx context.Context, c client.Client, infrastructure *extensionsv1alpha1.Infrastructure, providerStatus *metalapi.InfrastructureStatus, nodeCIDR *string) error {
-	patch := client.MergeFrom(infrastructure.DeepCopy())
-	infrastructure.Status.ProviderStatus = &runtime.RawExtension{Object: &metalv1alpha1.InfrastructureStatus{
+	status := &metalv1alpha1.InfrastructureStatus{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
 			Kind:       "InfrastructureStatus",
 		},
-		Firewall: metalv1alpha1.FirewallStatus{
-			MachineID: providerStatus.Firewall.MachineID,
-		},
-	}}
+	}
+	if err := metalv1alpha1.Convert_metal_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(providerStatus, status, nil); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(infrastructure.DeepCopy())
+	infrastructure.Status.ProviderStatus = &runtime.RawExtension{Object: status}
 	infrastructure.Status.NodesCIDR = nodeCIDR
 	return c.Status().Patch(ctx, infrastructure, patch)
 }
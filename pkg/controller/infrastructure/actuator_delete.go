@@ -30,12 +30,13 @@ type networkDeleter struct {
 	cluster              *controller.Cluster
 	infrastructure       *extensionsv1alpha1.Infrastructure
 	infrastructureConfig *metalapi.InfrastructureConfig
+	infrastructureStatus *metalapi.InfrastructureStatus
 	mclient              metalgo.Client
 	clusterID            string
 }
 
 func (a *actuator) Delete(ctx context.Context, logger logr.Logger, infrastructure *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
-	internalInfrastructureConfig, _, err := decodeInfrastructure(infrastructure, a.decoder)
+	internalInfrastructureConfig, internalInfrastructureStatus, err := decodeInfrastructure(infrastructure, a.decoder)
 	if err != nil {
 		return err
 	}
@@ -61,6 +62,7 @@ func (a *actuator) Delete(ctx context.Context, logger logr.Logger, infrastructur
 		cluster:              cluster,
 		infrastructure:       infrastructure,
 		infrastructureConfig: internalInfrastructureConfig,
+		infrastructureStatus: internalInfrastructureStatus,
 		mclient:              mclient,
 		clusterID:            string(cluster.Shoot.GetUID()),
 	}
@@ -145,6 +147,12 @@ func (a *actuator) releaseNetworkResources(d *networkDeleter) error {
 		}
 	}
 
+	for _, staticIP := range d.infrastructureStatus.StaticIPs {
+		if err := releaseStaticIP(d.ctx, d.mclient, d.infrastructureConfig.ProjectID, d.infrastructure.Namespace, d.clusterID, staticIP.Name); err != nil {
+			return fmt.Errorf("could not release static ip %s of reservation %q %w", staticIP.IP, staticIP.Name, err)
+		}
+	}
+
 	nodeCIDR, err := helper.GetNodeCIDR(d.infrastructure, d.cluster)
 	if err != nil {
 		return fmt.Errorf("unable to cleanup private networks as the node cidr is not defined: %w", err)
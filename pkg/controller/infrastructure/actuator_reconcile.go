@@ -3,7 +3,9 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -40,6 +42,17 @@ type networkReconciler struct {
 	clusterID            string
 }
 
+type staticIPReconciler struct {
+	logger               logr.Logger
+	infrastructureConfig *metalapi.InfrastructureConfig
+	controlPlaneConfig   *metalapi.ControlPlaneConfig
+	currentStaticIPs     []metalapi.StaticIPStatus
+	mclient              metalgo.Client
+	clusterID            string
+	clusterName          string
+	namespace            string
+}
+
 type egressIPReconciler struct {
 	logger               logr.Logger
 	infrastructureConfig *metalapi.InfrastructureConfig
@@ -85,6 +98,30 @@ func (a *actuator) Reconcile(ctx context.Context, logger logr.Logger, infrastruc
 		}
 	}
 
+	controlPlaneConfig, err := helper.ControlPlaneConfigFromClusterShootSpec(cluster)
+	if err != nil {
+		return err
+	}
+
+	staticIPReconciler := &staticIPReconciler{
+		logger:               logger,
+		infrastructureConfig: internalInfrastructureConfig,
+		controlPlaneConfig:   controlPlaneConfig,
+		currentStaticIPs:     internalInfrastructureStatus.StaticIPs,
+		mclient:              mclient,
+		clusterID:            string(cluster.Shoot.GetUID()),
+		clusterName:          cluster.Shoot.GetName(),
+		namespace:            infrastructure.Namespace,
+	}
+	staticIPs, err := reconcileStaticIPs(ctx, staticIPReconciler)
+	if err != nil {
+		return &reconciler.RequeueAfterError{
+			Cause:        err,
+			RequeueAfter: 30 * time.Second,
+		}
+	}
+	internalInfrastructureStatus.StaticIPs = staticIPs
+
 	err = updateProviderStatus(ctx, a.client, infrastructure, internalInfrastructureStatus, &nodeCIDR)
 	if err != nil {
 		return err
@@ -230,6 +267,174 @@ func reconcileEgressIPs(ctx context.Context, r *egressIPReconciler) error {
 	return nil
 }
 
+func reconcileStaticIPs(ctx context.Context, r *staticIPReconciler) ([]metalapi.StaticIPStatus, error) {
+	var (
+		result []metalapi.StaticIPStatus
+		wanted = sets.NewString()
+	)
+
+	for _, reservation := range r.controlPlaneConfig.StaticIPReservations {
+		wanted.Insert(reservation.Name)
+
+		if err := r.validateReservationNetwork(ctx, reservation); err != nil {
+			return nil, err
+		}
+
+		resp, err := r.mclient.IP().FindIPs(metalip.NewFindIPsParams().WithBody(&models.V1IPFindRequest{
+			Projectid: r.infrastructureConfig.ProjectID,
+			Tags:      []string{staticIPReservationTag(r.infrastructureConfig.ProjectID, r.namespace, reservation.Name)},
+		}).WithContext(ctx), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find static ip for reservation %q %w", reservation.Name, err)
+		}
+
+		var ip *models.V1IPResponse
+		switch len(resp.Payload) {
+		case 0:
+			ipType := models.V1IPBaseTypeStatic
+			if reservation.Ephemeral {
+				ipType = models.V1IPBaseTypeEphemeral
+			}
+
+			allocateResp, err := r.mclient.IP().AllocateIP(metalip.NewAllocateIPParams().WithBody(&models.V1IPAllocateRequest{
+				Name:        fmt.Sprintf("%s-%s", r.clusterName, reservation.Name),
+				Description: fmt.Sprintf("static ip reservation %q of cluster %s", reservation.Name, r.clusterID),
+				Networkid:   &reservation.Network,
+				Projectid:   &r.infrastructureConfig.ProjectID,
+				Type:        ipType,
+				Tags: []string{
+					fmt.Sprintf("%s=%s", tag.ClusterID, r.clusterID),
+					staticIPReservationTag(r.infrastructureConfig.ProjectID, r.namespace, reservation.Name),
+				},
+			}).WithContext(ctx), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to allocate static ip for reservation %q %w", reservation.Name, err)
+			}
+
+			r.logger.Info("allocated static ip for reservation", "reservation", reservation.Name, "ip", *allocateResp.Payload.Ipaddress)
+
+			ip = allocateResp.Payload
+		case 1:
+			ip, err = r.adoptStaticIP(ctx, reservation, resp.Payload[0])
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("static ip reservation %q found multiple times", reservation.Name)
+		}
+
+		if ip.Networkid != nil && *ip.Networkid != reservation.Network {
+			return nil, fmt.Errorf("static ip %s of reservation %q belongs to network %s but %s is specified", *ip.Ipaddress, reservation.Name, *ip.Networkid, reservation.Network)
+		}
+
+		result = append(result, metalapi.StaticIPStatus{
+			Name:    reservation.Name,
+			Network: reservation.Network,
+			IP:      *ip.Ipaddress,
+		})
+	}
+
+	for _, current := range r.currentStaticIPs {
+		if wanted.Has(current.Name) {
+			continue
+		}
+
+		err := releaseStaticIP(ctx, r.mclient, r.infrastructureConfig.ProjectID, r.namespace, r.clusterID, current.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not release static ip %s of reservation %q %w", current.IP, current.Name, err)
+		}
+
+		r.logger.Info("released static ip of removed reservation", "reservation", current.Name, "ip", current.IP)
+	}
+
+	return result, nil
+}
+
+// validateReservationNetwork ensures that the ip of a reservation is allocated either from one of the external
+// networks of the firewall or from a network of the partition of the cluster.
+func (r *staticIPReconciler) validateReservationNetwork(ctx context.Context, reservation metalapi.StaticIPReservation) error {
+	if slices.Contains(r.infrastructureConfig.Firewall.Networks, reservation.Network) {
+		return nil
+	}
+
+	resp, err := r.mclient.Network().FindNetwork(network.NewFindNetworkParams().WithID(reservation.Network).WithContext(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("failed to find network %s of static ip reservation %q %w", reservation.Network, reservation.Name, err)
+	}
+
+	if resp.Payload.Partitionid != r.infrastructureConfig.PartitionID {
+		return fmt.Errorf("network %s of static ip reservation %q is neither an external network of the firewall nor a network of partition %s", reservation.Network, reservation.Name, r.infrastructureConfig.PartitionID)
+	}
+
+	return nil
+}
+
+// adoptStaticIP takes over the ip of a reservation which is not in use by any cluster, e.g. because the cluster
+// which reserved it before was deleted and re-created. ips which are still in use by another cluster are refused.
+func (r *staticIPReconciler) adoptStaticIP(ctx context.Context, reservation metalapi.StaticIPReservation, ip *models.V1IPResponse) (*models.V1IPResponse, error) {
+	clusterTag := fmt.Sprintf("%s=%s", tag.ClusterID, r.clusterID)
+
+	for _, t := range ip.Tags {
+		key, value, _ := strings.Cut(t, "=")
+		if key != tag.ClusterID {
+			continue
+		}
+		if value != r.clusterID {
+			return nil, fmt.Errorf("static ip %s of reservation %q is in use by cluster %s", *ip.Ipaddress, reservation.Name, value)
+		}
+		return ip, nil
+	}
+
+	resp, err := r.mclient.IP().UpdateIP(metalip.NewUpdateIPParams().WithBody(&models.V1IPUpdateRequest{
+		Ipaddress: ip.Ipaddress,
+		Tags:      append(slices.Clone(ip.Tags), clusterTag),
+	}).WithContext(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adopt static ip %s for reservation %q %w", *ip.Ipaddress, reservation.Name, err)
+	}
+
+	r.logger.Info("adopted existing static ip for reservation", "reservation", reservation.Name, "ip", *ip.Ipaddress)
+
+	return resp.Payload, nil
+}
+
+// releaseStaticIP frees the ip of the given reservation if it is ephemeral, otherwise the ip is kept
+// and only the tags which reference the cluster are removed from it. the reservation tag is kept on the ip,
+// such that a re-created cluster with the same reservation adopts it again.
+func releaseStaticIP(ctx context.Context, mclient metalgo.Client, projectID, namespace, clusterID, name string) error {
+	resp, err := mclient.IP().FindIPs(metalip.NewFindIPsParams().WithBody(&models.V1IPFindRequest{
+		Projectid: projectID,
+		Tags:      []string{staticIPReservationTag(projectID, namespace, name)},
+	}).WithContext(ctx), nil)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range resp.Payload {
+		if ip.Type != nil && *ip.Type == models.V1IPBaseTypeEphemeral {
+			_, err := mclient.IP().FreeIP(metalip.NewFreeIPParams().WithID(*ip.Ipaddress).WithContext(ctx), nil)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := metalclient.RemoveClusterTagsFromIP(ctx, mclient, ip, clusterID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// staticIPReservationTag returns the tag of the ip of a reservation. the tag does not reference the cluster id as
+// it has to identify the ip across re-creations of the cluster. the namespace of the shoot in the seed contains the
+// name of the shoot, such that shoots of the same project do not adopt the ips of each other's reservations.
+func staticIPReservationTag(projectID, namespace, name string) string {
+	return fmt.Sprintf("%s=%s/%s/%s", metal.StaticIPReservationTag, projectID, namespace, name)
+}
+
 func egressTag(clusterID string) string {
 	return fmt.Sprintf("%s=%s", tag.ClusterEgress, clusterID)
 }
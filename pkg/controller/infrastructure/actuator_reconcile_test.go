@@ -0,0 +1,337 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	openapiruntime "github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metalgo "github.com/metal-stack/metal-go"
+	metalip "github.com/metal-stack/metal-go/api/client/ip"
+	"github.com/metal-stack/metal-go/api/client/network"
+	"github.com/metal-stack/metal-go/api/models"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+
+	metalapi "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+)
+
+type fakeMetalDriver struct {
+	metalgo.Client
+	ips      *fakeIPClient
+	networks *fakeNetworkClient
+}
+
+func (d *fakeMetalDriver) IP() metalip.ClientService {
+	return d.ips
+}
+
+func (d *fakeMetalDriver) Network() network.ClientService {
+	return d.networks
+}
+
+// fakeIPClient keeps the ips of the metal-api in memory.
+type fakeIPClient struct {
+	metalip.ClientService
+	ips []*models.V1IPResponse
+}
+
+func (c *fakeIPClient) FindIPs(params *metalip.FindIPsParams, _ openapiruntime.ClientAuthInfoWriter, _ ...metalip.ClientOption) (*metalip.FindIPsOK, error) {
+	var result []*models.V1IPResponse
+	for _, ip := range c.ips {
+		if params.Body.Projectid != "" && *ip.Projectid != params.Body.Projectid {
+			continue
+		}
+		if !containsAll(ip.Tags, params.Body.Tags) {
+			continue
+		}
+		result = append(result, ip)
+	}
+
+	return &metalip.FindIPsOK{Payload: result}, nil
+}
+
+func (c *fakeIPClient) AllocateIP(params *metalip.AllocateIPParams, _ openapiruntime.ClientAuthInfoWriter, _ ...metalip.ClientOption) (*metalip.AllocateIPCreated, error) {
+	ip := &models.V1IPResponse{
+		Ipaddress:   new(fmt.Sprintf("212.34.89.%d", len(c.ips)+1)),
+		Name:        params.Body.Name,
+		Description: params.Body.Description,
+		Networkid:   params.Body.Networkid,
+		Projectid:   params.Body.Projectid,
+		Type:        new(params.Body.Type),
+		Tags:        params.Body.Tags,
+	}
+	c.ips = append(c.ips, ip)
+
+	return &metalip.AllocateIPCreated{Payload: ip}, nil
+}
+
+func (c *fakeIPClient) UpdateIP(params *metalip.UpdateIPParams, _ openapiruntime.ClientAuthInfoWriter, _ ...metalip.ClientOption) (*metalip.UpdateIPOK, error) {
+	for _, ip := range c.ips {
+		if *ip.Ipaddress == *params.Body.Ipaddress {
+			ip.Tags = params.Body.Tags
+			return &metalip.UpdateIPOK{Payload: ip}, nil
+		}
+	}
+
+	return nil, errors.New("ip not found")
+}
+
+func (c *fakeIPClient) FreeIP(params *metalip.FreeIPParams, _ openapiruntime.ClientAuthInfoWriter, _ ...metalip.ClientOption) (*metalip.FreeIPOK, error) {
+	for i, ip := range c.ips {
+		if *ip.Ipaddress == params.ID {
+			c.ips = slices.Delete(c.ips, i, i+1)
+			return &metalip.FreeIPOK{Payload: ip}, nil
+		}
+	}
+
+	return nil, errors.New("ip not found")
+}
+
+type fakeNetworkClient struct {
+	network.ClientService
+	networks map[string]*models.V1NetworkResponse
+}
+
+func (c *fakeNetworkClient) FindNetwork(params *network.FindNetworkParams, _ openapiruntime.ClientAuthInfoWriter, _ ...network.ClientOption) (*network.FindNetworkOK, error) {
+	nw, ok := c.networks[params.ID]
+	if !ok {
+		return nil, errors.New("network not found")
+	}
+
+	return &network.FindNetworkOK{Payload: nw}, nil
+}
+
+func containsAll(tags []string, wanted []string) bool {
+	for _, t := range wanted {
+		if !slices.Contains(tags, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func Test_reconcileStaticIPs(t *testing.T) {
+	const (
+		projectID = "project-a"
+		namespace = "shoot--dev--web"
+		clusterID = "cluster-uid"
+	)
+
+	var (
+		clusterTag     = fmt.Sprintf("%s=%s", tag.ClusterID, clusterID)
+		reservationTag = func(namespace, name string) string {
+			return staticIPReservationTag(projectID, namespace, name)
+		}
+	)
+
+	tests := []struct {
+		name         string
+		reservations []metalapi.StaticIPReservation
+		current      []metalapi.StaticIPStatus
+		existing     []*models.V1IPResponse
+		want         []metalapi.StaticIPStatus
+		wantIPs      []*models.V1IPResponse
+		wantErr      string
+	}{
+		{
+			name: "allocates a static ip for a new reservation",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "internet"},
+			},
+			want: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.1"},
+			},
+			wantIPs: []*models.V1IPResponse{
+				{
+					Ipaddress:   new("212.34.89.1"),
+					Name:        "web-ingress",
+					Description: `static ip reservation "ingress" of cluster cluster-uid`,
+					Networkid:   new("internet"),
+					Projectid:   new(projectID),
+					Type:        new(models.V1IPBaseTypeStatic),
+					Tags:        []string{clusterTag, reservationTag(namespace, "ingress")},
+				},
+			},
+		},
+		{
+			name: "allocates an ephemeral ip for an ephemeral reservation",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "internet", Ephemeral: true},
+			},
+			want: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.1"},
+			},
+			wantIPs: []*models.V1IPResponse{
+				{
+					Ipaddress:   new("212.34.89.1"),
+					Name:        "web-ingress",
+					Description: `static ip reservation "ingress" of cluster cluster-uid`,
+					Networkid:   new("internet"),
+					Projectid:   new(projectID),
+					Type:        new(models.V1IPBaseTypeEphemeral),
+					Tags:        []string{clusterTag, reservationTag(namespace, "ingress")},
+				},
+			},
+		},
+		{
+			name: "keeps the ip of an existing reservation",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "internet"},
+			},
+			current: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.10"},
+			},
+			existing: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{clusterTag, reservationTag(namespace, "ingress")}},
+			},
+			want: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.10"},
+			},
+			wantIPs: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{clusterTag, reservationTag(namespace, "ingress")}},
+			},
+		},
+		{
+			name: "adopts the ip of a reservation after the re-creation of the cluster",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "internet"},
+			},
+			existing: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{reservationTag(namespace, "ingress")}},
+			},
+			want: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.10"},
+			},
+			wantIPs: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{reservationTag(namespace, "ingress"), clusterTag}},
+			},
+		},
+		{
+			name: "does not adopt the ip of a reservation with the same name of another shoot in the project",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "internet"},
+			},
+			existing: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{reservationTag("shoot--dev--api", "ingress")}},
+			},
+			want: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.2"},
+			},
+			wantIPs: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{reservationTag("shoot--dev--api", "ingress")}},
+				{
+					Ipaddress:   new("212.34.89.2"),
+					Name:        "web-ingress",
+					Description: `static ip reservation "ingress" of cluster cluster-uid`,
+					Networkid:   new("internet"),
+					Projectid:   new(projectID),
+					Type:        new(models.V1IPBaseTypeStatic),
+					Tags:        []string{clusterTag, reservationTag(namespace, "ingress")},
+				},
+			},
+		},
+		{
+			name: "refuses the ip of a reservation which is in use by another cluster",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "internet"},
+			},
+			existing: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{tag.ClusterID + "=other-uid", reservationTag(namespace, "ingress")}},
+			},
+			wantErr: `static ip 212.34.89.10 of reservation "ingress" is in use by cluster other-uid`,
+		},
+		{
+			name: "refuses an ip of a reservation whose network changed",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "partition-network"},
+			},
+			existing: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{clusterTag, reservationTag(namespace, "ingress")}},
+			},
+			wantErr: `static ip 212.34.89.10 of reservation "ingress" belongs to network internet but partition-network is specified`,
+		},
+		{
+			name: "refuses a network of another partition",
+			reservations: []metalapi.StaticIPReservation{
+				{Name: "ingress", Network: "foreign-network"},
+			},
+			wantErr: `network foreign-network of static ip reservation "ingress" is neither an external network of the firewall nor a network of partition partition-a`,
+		},
+		{
+			name: "keeps the ip of a removed static reservation without the cluster tags",
+			current: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.10"},
+			},
+			existing: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{clusterTag, reservationTag(namespace, "ingress")}},
+			},
+			wantIPs: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeStatic), Tags: []string{reservationTag(namespace, "ingress")}},
+			},
+		},
+		{
+			name: "frees the ip of a removed ephemeral reservation",
+			current: []metalapi.StaticIPStatus{
+				{Name: "ingress", Network: "internet", IP: "212.34.89.10"},
+			},
+			existing: []*models.V1IPResponse{
+				{Ipaddress: new("212.34.89.10"), Networkid: new("internet"), Projectid: new(projectID), Type: new(models.V1IPBaseTypeEphemeral), Tags: []string{clusterTag, reservationTag(namespace, "ingress")}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ips := &fakeIPClient{ips: tt.existing}
+
+			r := &staticIPReconciler{
+				logger: logr.Discard(),
+				infrastructureConfig: &metalapi.InfrastructureConfig{
+					ProjectID:   projectID,
+					PartitionID: "partition-a",
+					Firewall: metalapi.Firewall{
+						Networks: []string{"internet"},
+					},
+				},
+				controlPlaneConfig: &metalapi.ControlPlaneConfig{
+					StaticIPReservations: tt.reservations,
+				},
+				currentStaticIPs: tt.current,
+				mclient: &fakeMetalDriver{
+					ips: ips,
+					networks: &fakeNetworkClient{
+						networks: map[string]*models.V1NetworkResponse{
+							"partition-network": {ID: new("partition-network"), Partitionid: "partition-a"},
+							"foreign-network":   {ID: new("foreign-network"), Partitionid: "partition-b"},
+						},
+					},
+				},
+				clusterID:   clusterID,
+				clusterName: "web",
+				namespace:   namespace,
+			}
+
+			got, err := reconcileStaticIPs(context.Background(), r)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantIPs, ips.ips, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ips diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
@@ -138,7 +138,20 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, worker *exten
 		return err
 	}
 
-	return a.workerActuator.Reconcile(ctx, log, worker, cluster)
+	err = a.workerActuator.Reconcile(ctx, log, worker, cluster)
+	if err != nil {
+		return err
+	}
+
+	// the worker nodes need to be reconciled during a network access type migration, so a pending migration
+	// is only reported after the worker reconciliation. the reconciliation itself succeeds, the worker is
+	// reconciled again by the network access migration controller until the migration is completed.
+	workerStatus, err := a.decodeWorkerProviderStatus(worker)
+	if err != nil {
+		return err
+	}
+
+	return a.updateNetworkAccessMigrationCondition(ctx, worker, workerStatus.NetworkAccess)
 }
 
 func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) error {
@@ -11,6 +11,8 @@ import (
 	machinescheme "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/scheme"
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -47,13 +49,25 @@ func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddO
 		return err
 	}
 
-	return worker.Add(ctx, mgr, worker.AddArgs{
+	err := worker.Add(ctx, mgr, worker.AddArgs{
 		Actuator:          NewActuator(mgr, opts.GardenCluster, opts.MachineImages, opts.ControllerConfig),
 		ControllerOptions: opts.Controller,
 		Predicates:        worker.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
 		Type:              metal.Type,
 		ExtensionClasses:  opts.ExtensionClasses,
 	})
+	if err != nil {
+		return err
+	}
+
+	return builder.ControllerManagedBy(mgr).
+		Named(NetworkAccessMigrationControllerName).
+		WithOptions(opts.Controller).
+		For(&extensionsv1alpha1.Worker{}).
+		Complete(&networkAccessMigrationReconciler{
+			client: mgr.GetClient(),
+			clock:  clock.RealClock{},
+		})
 }
 
 // AddToManager adds a controller with the default Options.
@@ -3,8 +3,8 @@ package worker
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
-	"strings"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
@@ -14,6 +14,7 @@ import (
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/validation"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	metalclient "github.com/metal-stack/gardener-extension-provider-metal/pkg/metal/client"
 	metalcommon "github.com/metal-stack/metal-lib/pkg/metal"
 	"github.com/metal-stack/metal-lib/pkg/tag"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -56,11 +57,24 @@ func (a *actuator) firewallReconcile(ctx context.Context, log logr.Logger, worke
 		return fmt.Errorf("error getting additional data: %w", err)
 	}
 
-	err = a.ensureFirewallDeployment(ctx, log, d, cluster, string(sshSecret.Data["id_rsa.pub"]))
+	networkAccessType, migration, err := a.reconcileNetworkAccess(ctx, log, worker, cluster, d)
+	if err != nil {
+		return fmt.Errorf("error reconciling network access type: %w", err)
+	}
+
+	err = a.ensureFirewallDeployment(ctx, log, d, cluster, string(sshSecret.Data["id_rsa.pub"]), networkAccessType)
 	if err != nil {
 		return err
 	}
 
+	err = a.updateNetworkAccessStatus(ctx, worker, &apismetal.NetworkAccessStatus{
+		Type:      networkAccessType,
+		Migration: migration,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update network access status: %w", err)
+	}
+
 	err = a.updateState(ctx, log, d.infrastructure)
 	if err != nil {
 		return fmt.Errorf("unable to update firewall state: %w", err)
@@ -69,7 +83,7 @@ func (a *actuator) firewallReconcile(ctx context.Context, log logr.Logger, worke
 	return nil
 }
 
-func (a *actuator) ensureFirewallDeployment(ctx context.Context, log logr.Logger, d *additionalData, cluster *extensionscontroller.Cluster, sshKey string) error {
+func (a *actuator) ensureFirewallDeployment(ctx context.Context, log logr.Logger, d *additionalData, cluster *extensionscontroller.Cluster, sshKey string, networkAccessType apismetal.NetworkAccessType) error {
 	var (
 		clusterID = string(cluster.Shoot.GetUID())
 		namespace = cluster.ObjectMeta.Name
@@ -95,17 +109,7 @@ func (a *actuator) ensureFirewallDeployment(ctx context.Context, log logr.Logger
 		},
 	}
 
-	controlPlaneConfig, err := helper.ControlPlaneConfigFromClusterShootSpec(cluster)
-	if err != nil {
-		return err
-	}
-
-	networkAccessType := apismetal.NetworkAccessBaseline
-	if controlPlaneConfig.NetworkAccessType != nil {
-		networkAccessType = *controlPlaneConfig.NetworkAccessType
-	}
-
-	_, err = controllerutil.CreateOrUpdate(ctx, a.client, deploy, func() error {
+	op, err := controllerutil.CreateOrUpdate(ctx, a.client, deploy, func() error {
 		if deploy.Annotations == nil {
 			deploy.Annotations = map[string]string{}
 		}
@@ -155,22 +159,16 @@ func (a *actuator) ensureFirewallDeployment(ctx context.Context, log logr.Logger
 		deploy.Spec.Template.Spec.LogAcceptedConnections = d.infrastructureConfig.Firewall.LogAcceptedConnections
 		deploy.Spec.Template.Spec.SSHPublicKeys = []string{sshKey}
 
-		if d.partition.NetworkIsolation != nil &&
-			len(d.partition.NetworkIsolation.DNSServers) > 0 &&
-			networkAccessType != apismetal.NetworkAccessBaseline {
-			dnsAddr, portStr, ok := strings.Cut(d.partition.NetworkIsolation.DNSServers[0], ":")
-			deploy.Spec.Template.Spec.DNSServerAddress = dnsAddr
-
-			if ok {
-				p, err := strconv.ParseUint(portStr, 10, 64)
-				if err != nil {
-					return fmt.Errorf("invalid dns port:%q", portStr)
-				}
-				port := uint(p)
-				deploy.Spec.Template.Spec.DNSPort = &port
+		deploy.Spec.Template.Spec.DNSServerAddress = ""
+		deploy.Spec.Template.Spec.DNSPort = nil
+		if d.partition.NetworkIsolation != nil && networkAccessType != apismetal.NetworkAccessBaseline {
+			// the dns proxy of the firewall forwards queries over udp, so servers which are only reachable over tcp are skipped
+			idx := slices.IndexFunc(d.partition.NetworkIsolation.DNSServers, func(s apismetal.NetworkServer) bool {
+				return s.Protocol == "" || s.Protocol == apismetal.NetworkServerProtocolUDP
+			})
+			if idx >= 0 {
+				deploy.Spec.Template.Spec.DNSServerAddress = d.partition.NetworkIsolation.DNSServers[idx].IP
 			}
-		} else {
-			deploy.Spec.Template.Spec.DNSServerAddress = ""
 		}
 
 		if networkAccessType == apismetal.NetworkAccessForbidden {
@@ -182,19 +180,39 @@ func (a *actuator) ensureFirewallDeployment(ctx context.Context, log logr.Logger
 				Ingress: d.partition.NetworkIsolation.AllowedNetworks.Ingress,
 				Egress:  d.partition.NetworkIsolation.AllowedNetworks.Egress,
 			}
+			if additional := d.cpConfig.AdditionalAllowedNetworks; additional != nil {
+				deploy.Spec.Template.Spec.AllowedNetworks = fcmv2.AllowedNetworks{
+					Ingress: mergeNetworks(d.partition.NetworkIsolation.AllowedNetworks.Ingress, additional.Ingress),
+					Egress:  mergeNetworks(d.partition.NetworkIsolation.AllowedNetworks.Egress, additional.Egress),
+				}
+			}
 		}
 
 		return nil
 	})
 	if err != nil {
+		metalclient.FirewallDeploymentReconcilesTotal.WithLabelValues(d.mcp.Endpoint, "error").Inc()
 		return fmt.Errorf("error creating firewall deployment: %w", err)
 	}
 
+	metalclient.FirewallDeploymentReconcilesTotal.WithLabelValues(d.mcp.Endpoint, string(op)).Inc()
+
 	log.Info("reconciled firewall deployment", "name", deploy.Name, "cluster-id", clusterID)
 
 	return nil
 }
 
+// mergeNetworks returns the given networks followed by the additional networks which are not yet contained.
+func mergeNetworks(networks, additional []string) []string {
+	result := slices.Clone(networks)
+	for _, n := range additional {
+		if !slices.Contains(result, n) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
 func mapRateLimits(limits []apismetal.RateLimit) []fcmv2.RateLimit {
 	var result []fcmv2.RateLimit
 	for _, l := range limits {
@@ -78,3 +78,39 @@ func Test_patchUpdate(t *testing.T) {
 		})
 	}
 }
+
+func Test_mergeNetworks(t *testing.T) {
+	tests := []struct {
+		name       string
+		networks   []string
+		additional []string
+		want       []string
+	}{
+		{
+			name:       "no additional networks",
+			networks:   []string{"10.0.0.0/24"},
+			additional: nil,
+			want:       []string{"10.0.0.0/24"},
+		},
+		{
+			name:       "additional networks are appended",
+			networks:   []string{"10.0.0.0/24"},
+			additional: []string{"10.1.0.0/24", "10.2.0.0/24"},
+			want:       []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"},
+		},
+		{
+			name:       "duplicates are skipped",
+			networks:   []string{"10.0.0.0/24", "10.1.0.0/24"},
+			additional: []string{"10.1.0.0/24", "10.2.0.0/24"},
+			want:       []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeNetworks(tt.networks, tt.additional)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
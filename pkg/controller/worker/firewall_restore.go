@@ -62,7 +62,18 @@ func (a *actuator) firewallRestore(ctx context.Context, log logr.Logger, worker
 		return fmt.Errorf("could not find current ssh secret: %w", err)
 	}
 
-	err = a.ensureFirewallDeployment(ctx, log, d, cluster, string(sshSecret.Data["id_rsa.pub"]))
+	workerStatus, err := a.decodeWorkerProviderStatus(worker)
+	if err != nil {
+		return err
+	}
+
+	// the firewall deployment is restored with the network access type that was applied before the migration
+	networkAccessType := networkAccessTypeFromConfig(d.cpConfig)
+	if workerStatus.NetworkAccess != nil {
+		networkAccessType = workerStatus.NetworkAccess.Type
+	}
+
+	err = a.ensureFirewallDeployment(ctx, log, d, cluster, string(sshSecret.Data["id_rsa.pub"]), networkAccessType)
 	if err != nil {
 		return err
 	}
@@ -29,6 +29,9 @@ type (
 		partition            *apismetal.Partition
 		cloudProfileConfig   *apismetal.CloudProfileConfig
 		cpConfig             *apismetal.ControlPlaneConfig
+		// networkAccessType is the network access type applied to the firewall, it lags behind the type of the control
+		// plane config while a migration to a stricter type is pending
+		networkAccessType apismetal.NetworkAccessType
 	}
 
 	key int
@@ -102,6 +105,11 @@ func (a *actuator) getAdditionalData(ctx context.Context, worker *extensionsv1al
 		return nil, fmt.Errorf("private network id is nil")
 	}
 
+	networkAccessType, err := helper.AppliedNetworkAccessType(worker, networkAccessTypeFromConfig(cpConfig))
+	if err != nil {
+		return nil, err
+	}
+
 	return &additionalData{
 		mcp:                  metalControlPlane,
 		infrastructure:       infrastructure,
@@ -112,6 +120,7 @@ func (a *actuator) getAdditionalData(ctx context.Context, worker *extensionsv1al
 		partition:            partition,
 		cloudProfileConfig:   cloudProfileConfig,
 		cpConfig:             cpConfig,
+		networkAccessType:    networkAccessType,
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"strings"
 
@@ -17,6 +18,7 @@ import (
 
 	"github.com/metal-stack/gardener-extension-provider-metal/charts"
 	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -130,6 +132,11 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 	}
 
 	for _, pool := range w.worker.Spec.Pools {
+		workerConfig, err := helper.WorkerConfigFromRawExtension(pool.ProviderConfig)
+		if err != nil {
+			return fmt.Errorf("could not decode providerConfig of worker pool %q: %w", pool.Name, err)
+		}
+
 		machineImage, err := w.findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version)
 		if err != nil {
 			return err
@@ -172,6 +179,13 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		for k, v := range pool.Labels {
 			tags = append(tags, fmt.Sprintf("%s=%s", k, v))
 		}
+		tags = append(tags, workerConfig.Tags...)
+
+		// a pinned image only applies to the machine class, the worker status keeps reporting the image of the cloud
+		// profile which belongs to the machine image name and version of the pool
+		if workerConfig.ImageID != nil {
+			machineImage = *workerConfig.ImageID
+		}
 
 		machineClassSpec := map[string]any{
 			"partition": w.additionalData.infrastructureConfig.PartitionID,
@@ -190,12 +204,20 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 			},
 		}
 
+		if len(workerConfig.AdditionalNetworks) > 0 {
+			machineClassSpec["additionalNetworks"] = workerConfig.AdditionalNetworks
+		}
+		if len(workerConfig.PlacementTags) > 0 {
+			machineClassSpec["placementTags"] = workerConfig.PlacementTags
+		}
+
+		// machines only accept plain addresses, the validation of the cloud profile ensures that the servers use their default ports
 		if dnsServers := w.dnsServers(); len(dnsServers) > 0 {
 			var servers []map[string]string
 
 			for _, s := range dnsServers {
 				servers = append(servers, map[string]string{
-					"ip": s,
+					"ip": s.IP,
 				})
 			}
 
@@ -207,14 +229,16 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 
 			for _, s := range ntpServers {
 				servers = append(servers, map[string]string{
-					"address": s,
+					"address": s.IP,
 				})
 			}
 
 			machineClassSpec["ntpServers"] = servers
 		}
 
-		workerPoolHash, err := worker.WorkerPoolHash(pool, w.cluster, nil, nil, nil)
+		additionalHashData := workerConfigHashData(workerConfig)
+
+		workerPoolHash, err := worker.WorkerPoolHash(pool, w.cluster, additionalHashData, additionalHashData, nil)
 		if err != nil {
 			return err
 		}
@@ -268,8 +292,7 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		// if we'd move the endpoint out of this secret into the deployment spec (which would be the way to go)
 		// it would roll all worker nodes...
 		machineClassSpec["secret"].(map[string]any)["metalAPIURL"] = w.additionalData.mcp.Endpoint
-		machineClassSpec["secret"].(map[string]any)[metal.APIKey] = w.additionalData.credentials.MetalAPIKey
-		machineClassSpec["secret"].(map[string]any)[metal.APIHMac] = w.additionalData.credentials.MetalAPIHMac
+		maps.Copy(machineClassSpec["secret"].(map[string]any), w.machineClassSecretCredentials())
 
 		machineClasses = append(machineClasses, machineClassSpec)
 	}
@@ -281,7 +304,28 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 	return nil
 }
 
-func (w *workerDelegate) dnsServers() []string {
+// workerConfigHashData returns the settings of the worker config which require new machines when they change.
+// Nothing is returned for an empty worker config, such that the hash of existing worker pools is not changed.
+func workerConfigHashData(workerConfig *apismetal.WorkerConfig) []string {
+	var data []string
+
+	if len(workerConfig.AdditionalNetworks) > 0 {
+		data = append(data, "additionalNetworks="+strings.Join(workerConfig.AdditionalNetworks, ","))
+	}
+	if len(workerConfig.Tags) > 0 {
+		data = append(data, "tags="+strings.Join(workerConfig.Tags, ","))
+	}
+	if len(workerConfig.PlacementTags) > 0 {
+		data = append(data, "placementTags="+strings.Join(workerConfig.PlacementTags, ","))
+	}
+	if workerConfig.ImageID != nil {
+		data = append(data, "imageID="+*workerConfig.ImageID)
+	}
+
+	return data
+}
+
+func (w *workerDelegate) dnsServers() []apismetal.NetworkServer {
 	nw := w.networkIsolationIfEnabled()
 	if nw == nil {
 		return nil
@@ -290,7 +334,7 @@ func (w *workerDelegate) dnsServers() []string {
 	return nw.DNSServers
 }
 
-func (w *workerDelegate) ntpServers() []string {
+func (w *workerDelegate) ntpServers() []apismetal.NetworkServer {
 	nw := w.networkIsolationIfEnabled()
 	if nw == nil {
 		return nil
@@ -308,7 +352,9 @@ func (w *workerDelegate) networkIsolationIfEnabled() *apismetal.NetworkIsolation
 		return nil
 	}
 
-	if w.additionalData.cpConfig.NetworkAccessType == nil || *w.additionalData.cpConfig.NetworkAccessType == apismetal.NetworkAccessBaseline {
+	// the machines follow the network access type applied to the firewall, such that they are only switched
+	// once a migration to a stricter type has completed
+	if w.additionalData.networkAccessType == "" || w.additionalData.networkAccessType == apismetal.NetworkAccessBaseline {
 		return nil
 	}
 
@@ -319,6 +365,27 @@ func (w *workerDelegate) networkIsolationIfEnabled() *apismetal.NetworkIsolation
 	return w.additionalData.partition.NetworkIsolation
 }
 
+// machineClassSecretCredentials returns the metal-api credentials which are copied into the machine class secrets.
+// the content of the machine class secrets is not part of the worker pool hash, so omitting the credentials does
+// not replace the machines of the worker pools.
+func (w *workerDelegate) machineClassSecretCredentials() map[string]any {
+	credentials := w.additionalData.credentials
+
+	// tokens of workload identities are rotated by gardener, they are only read from the credentials secret ref
+	if credentials.WorkloadIdentity {
+		return nil
+	}
+
+	if w.controllerConfig.MachineClasses != nil && w.controllerConfig.MachineClasses.CredentialsFromSecretRefOnly {
+		return nil
+	}
+
+	return map[string]any{
+		metal.APIKey:  credentials.MetalAPIKey,
+		metal.APIHMac: credentials.MetalAPIHMac,
+	}
+}
+
 func explicitWorkerHashAnnotation(groupName string) string {
 	return explicitWorkerHashAnnotationPrefix + groupName
 }
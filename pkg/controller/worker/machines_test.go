@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+)
+
+func Test_machineClassSecretCredentials(t *testing.T) {
+	tests := []struct {
+		name             string
+		credentials      *metal.Credentials
+		controllerConfig config.ControllerConfiguration
+		want             map[string]any
+	}{
+		{
+			name:        "credentials are copied by default",
+			credentials: &metal.Credentials{MetalAPIHMac: "hmac"},
+			want: map[string]any{
+				"metalAPIKey":  "",
+				"metalAPIHMac": "hmac",
+			},
+		},
+		{
+			name:        "credentials are omitted when read from the secret ref only",
+			credentials: &metal.Credentials{MetalAPIHMac: "hmac"},
+			controllerConfig: config.ControllerConfiguration{
+				MachineClasses: &config.MachineClasses{
+					CredentialsFromSecretRefOnly: true,
+				},
+			},
+			want: nil,
+		},
+		{
+			name:        "tokens of workload identities are never copied",
+			credentials: &metal.Credentials{MetalAPIKey: "token", WorkloadIdentity: true},
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &workerDelegate{
+				controllerConfig: tt.controllerConfig,
+				additionalData: &additionalData{
+					credentials: tt.credentials,
+				},
+			}
+
+			got := w.machineClassSecretCredentials()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("machineClassSecretCredentials() diff = %s", diff)
+			}
+		})
+	}
+}
+
+func Test_workerConfigHashData(t *testing.T) {
+	tests := []struct {
+		name         string
+		workerConfig *apismetal.WorkerConfig
+		want         []string
+	}{
+		{
+			name:         "empty worker config does not change the hash",
+			workerConfig: &apismetal.WorkerConfig{},
+			want:         nil,
+		},
+		{
+			name: "all settings are part of the hash",
+			workerConfig: &apismetal.WorkerConfig{
+				AdditionalNetworks: []string{"storage", "mpls"},
+				Tags:               []string{"team=ci"},
+				PlacementTags:      []string{"ci"},
+				ImageID:            new("ubuntu-24.4-20250101"),
+			},
+			want: []string{
+				"additionalNetworks=storage,mpls",
+				"tags=team=ci",
+				"placementTags=ci",
+				"imageID=ubuntu-24.4-20250101",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := workerConfigHashData(tt.workerConfig)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("workerConfigHashData() diff = %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	extensionsconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/gardener/gardener/extensions/pkg/util"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	fcmv2 "github.com/metal-stack/firewall-controller-manager/api/v2"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileNetworkAccess determines the network access type which can be applied to the firewall deployment.
+//
+// a migration to a stricter network access type is only carried out when all images running in the shoot are served
+// by the registry mirrors of the partition and, in case of forbidden, all load balancer ips are contained in the allowed
+// ingress networks. otherwise the currently applied network access type is kept and the migration is returned such
+// that its progress can be reported.
+func (a *actuator) reconcileNetworkAccess(ctx context.Context, log logr.Logger, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster, d *additionalData) (apismetal.NetworkAccessType, *apismetal.NetworkAccessMigration, error) {
+	desired := networkAccessTypeFromConfig(d.cpConfig)
+
+	applied, err := a.appliedNetworkAccessType(ctx, worker, cluster, desired)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if networkAccessRank(desired) <= networkAccessRank(applied) {
+		return desired, nil, nil
+	}
+
+	if d.partition.NetworkIsolation == nil {
+		return "", nil, fmt.Errorf("network access type %s requires partition %q to have a network isolation", desired, d.infrastructureConfig.PartitionID)
+	}
+
+	log.Info("migrating network access type", "from", applied, "to", desired)
+
+	_, shootClient, err := util.NewClientForShoot(ctx, a.client, cluster.ObjectMeta.Name, client.Options{}, extensionsconfigv1alpha1.RESTOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create shoot client: %w", err)
+	}
+
+	pods := &corev1.PodList{}
+	err = shootClient.List(ctx, pods)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to list pods of shoot: %w", err)
+	}
+
+	var (
+		messages        []string
+		uncoveredImages = helper.UncoveredImages(d.partition.NetworkIsolation.RegistryMirrors, podImages(pods.Items))
+	)
+
+	if len(uncoveredImages) > 0 {
+		messages = append(messages, fmt.Sprintf("%d images are not served by a registry mirror of the partition", len(uncoveredImages)))
+	}
+
+	if desired == apismetal.NetworkAccessForbidden {
+		services := &corev1.ServiceList{}
+		err = shootClient.List(ctx, services)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to list services of shoot: %w", err)
+		}
+
+		ingress := d.partition.NetworkIsolation.AllowedNetworks.Ingress
+		if d.cpConfig.AdditionalAllowedNetworks != nil {
+			ingress = mergeNetworks(ingress, d.cpConfig.AdditionalAllowedNetworks.Ingress)
+		}
+
+		if ips := loadBalancerIPsOutsideOf(services.Items, ingress); len(ips) > 0 {
+			messages = append(messages, fmt.Sprintf("load balancer ips are not contained in the allowed ingress networks: %s", strings.Join(ips, ", ")))
+		}
+	}
+
+	if len(messages) > 0 {
+		log.Info("network access type migration is blocked", "from", applied, "to", desired, "reasons", messages)
+
+		return applied, &apismetal.NetworkAccessMigration{
+			Target:          desired,
+			Message:         strings.Join(messages, "; "),
+			UncoveredImages: uncoveredImages,
+		}, nil
+	}
+
+	log.Info("network access type migration completed", "from", applied, "to", desired)
+
+	return desired, nil, nil
+}
+
+// appliedNetworkAccessType returns the network access type that is currently applied to the firewall deployment.
+// for workers without network access status, it is derived from the existing firewall deployment.
+func (a *actuator) appliedNetworkAccessType(ctx context.Context, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster, desired apismetal.NetworkAccessType) (apismetal.NetworkAccessType, error) {
+	workerStatus, err := a.decodeWorkerProviderStatus(worker)
+	if err != nil {
+		return "", err
+	}
+
+	if workerStatus.NetworkAccess != nil {
+		return workerStatus.NetworkAccess.Type, nil
+	}
+
+	deploy := &fcmv2.FirewallDeployment{}
+	err = a.client.Get(ctx, client.ObjectKey{Name: metal.FirewallDeploymentName, Namespace: cluster.ObjectMeta.Name}, deploy)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// a new cluster starts with the desired network access type
+			return desired, nil
+		}
+		return "", fmt.Errorf("unable to get firewall deployment: %w", err)
+	}
+
+	return networkAccessTypeFromFirewallDeployment(deploy), nil
+}
+
+func (a *actuator) updateNetworkAccessStatus(ctx context.Context, worker *extensionsv1alpha1.Worker, status *apismetal.NetworkAccessStatus) error {
+	workerStatus, err := a.decodeWorkerProviderStatus(worker)
+	if err != nil {
+		return err
+	}
+
+	workerStatus.NetworkAccess = status
+
+	workerStatusV1alpha1 := &v1alpha1.WorkerStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "WorkerStatus",
+		},
+	}
+
+	if err := a.scheme.Convert(workerStatus, workerStatusV1alpha1, nil); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(worker.DeepCopy())
+	worker.Status.ProviderStatus = &runtime.RawExtension{Object: workerStatusV1alpha1}
+	return a.client.Status().Patch(ctx, worker, patch)
+}
+
+// updateNetworkAccessMigrationCondition reports a pending migration of the network access type in a condition of the
+// worker. the condition is only added once a migration is pending, afterwards it reports the applied type.
+func (a *actuator) updateNetworkAccessMigrationCondition(ctx context.Context, worker *extensionsv1alpha1.Worker, status *apismetal.NetworkAccessStatus) error {
+	var (
+		conditionStatus gardencorev1beta1.ConditionStatus
+		reason, message string
+	)
+
+	switch {
+	case status != nil && status.Migration != nil:
+		conditionStatus = gardencorev1beta1.ConditionProgressing
+		reason = reasonNetworkAccessMigrationPending
+		message = fmt.Sprintf("migration of network access type to %s is pending: %s", status.Migration.Target, status.Migration.Message)
+	case status != nil && v1beta1helper.GetCondition(worker.Status.Conditions, ConditionTypeNetworkAccessMigration) != nil:
+		conditionStatus = gardencorev1beta1.ConditionTrue
+		reason = reasonNetworkAccessMigrationCompleted
+		message = fmt.Sprintf("network access type %s is applied", status.Type)
+	default:
+		return nil
+	}
+
+	patch := client.MergeFrom(worker.DeepCopy())
+
+	condition := v1beta1helper.GetOrInitConditionWithClock(clock.RealClock{}, worker.Status.Conditions, ConditionTypeNetworkAccessMigration)
+	condition = v1beta1helper.UpdatedConditionWithClock(clock.RealClock{}, condition, conditionStatus, reason, message)
+	worker.Status.Conditions = v1beta1helper.MergeConditions(worker.Status.Conditions, condition)
+
+	return a.client.Status().Patch(ctx, worker, patch)
+}
+
+func (a *actuator) decodeWorkerProviderStatus(worker *extensionsv1alpha1.Worker) (*apismetal.WorkerStatus, error) {
+	workerStatus := &apismetal.WorkerStatus{}
+
+	if worker.Status.ProviderStatus == nil {
+		return workerStatus, nil
+	}
+
+	if _, _, err := a.decoder.Decode(worker.Status.ProviderStatus.Raw, nil, workerStatus); err != nil {
+		return nil, fmt.Errorf("could not decode WorkerStatus '%s' %w", worker.Name, err)
+	}
+
+	return workerStatus, nil
+}
+
+func networkAccessTypeFromConfig(cpConfig *apismetal.ControlPlaneConfig) apismetal.NetworkAccessType {
+	if cpConfig == nil || cpConfig.NetworkAccessType == nil {
+		return apismetal.NetworkAccessBaseline
+	}
+	return *cpConfig.NetworkAccessType
+}
+
+func networkAccessTypeFromFirewallDeployment(deploy *fcmv2.FirewallDeployment) apismetal.NetworkAccessType {
+	spec := deploy.Spec.Template.Spec
+	if len(spec.AllowedNetworks.Ingress) > 0 || len(spec.AllowedNetworks.Egress) > 0 {
+		return apismetal.NetworkAccessForbidden
+	}
+	if spec.DNSServerAddress != "" {
+		return apismetal.NetworkAccessRestricted
+	}
+	return apismetal.NetworkAccessBaseline
+}
+
+func networkAccessRank(t apismetal.NetworkAccessType) int {
+	switch t {
+	case apismetal.NetworkAccessRestricted:
+		return 1
+	case apismetal.NetworkAccessForbidden:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func podImages(pods []corev1.Pod) []string {
+	var images []string
+	for _, pod := range pods {
+		for _, c := range pod.Spec.InitContainers {
+			images = append(images, c.Image)
+		}
+		for _, c := range pod.Spec.Containers {
+			images = append(images, c.Image)
+		}
+	}
+	return images
+}
+
+func loadBalancerIPsOutsideOf(services []corev1.Service, cidrs []string) []string {
+	var ips []string
+	for _, svc := range services {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			ips = append(ips, ingress.IP)
+		}
+	}
+
+	return helper.IPsOutsideOf(ips, cidrs)
+}
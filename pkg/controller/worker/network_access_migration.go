@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// NetworkAccessMigrationControllerName is the name of the controller which reconciles workers with a pending
+	// migration of the network access type again.
+	NetworkAccessMigrationControllerName = "metal-network-access-migration"
+
+	// ConditionTypeNetworkAccessMigration is the type of the condition in the worker status which reports a pending
+	// migration to a stricter network access type.
+	ConditionTypeNetworkAccessMigration gardencorev1beta1.ConditionType = "NetworkAccessMigration"
+
+	reasonNetworkAccessMigrationPending   = "NetworkAccessMigrationPending"
+	reasonNetworkAccessMigrationCompleted = "NetworkAccessMigrationCompleted"
+	networkAccessMigrationRequeuePeriod   = 1 * time.Minute
+)
+
+type networkAccessMigrationReconciler struct {
+	client client.Client
+	clock  clock.Clock
+}
+
+// Reconcile triggers a reconciliation of a worker with a pending network access type migration, such that the migration
+// is carried out as soon as its preconditions are met. the worker is only reconciled again when its last reconciliation
+// succeeded, failed reconciliations are retried by the worker controller itself.
+func (r *networkAccessMigrationReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	worker := &extensionsv1alpha1.Worker{}
+	if err := r.client.Get(ctx, req.NamespacedName, worker); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if worker.Spec.Type != metal.Type || worker.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	condition := v1beta1helper.GetCondition(worker.Status.Conditions, ConditionTypeNetworkAccessMigration)
+	if condition == nil || condition.Status != gardencorev1beta1.ConditionProgressing {
+		return reconcile.Result{}, nil
+	}
+
+	if worker.Annotations[v1beta1constants.GardenerOperation] == v1beta1constants.GardenerOperationReconcile {
+		return reconcile.Result{}, nil
+	}
+
+	lastOperation := worker.Status.LastOperation
+	if lastOperation == nil || lastOperation.State != gardencorev1beta1.LastOperationStateSucceeded {
+		return reconcile.Result{}, nil
+	}
+
+	if remaining := lastOperation.LastUpdateTime.Add(networkAccessMigrationRequeuePeriod).Sub(r.clock.Now()); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	patch := client.MergeFrom(worker.DeepCopy())
+	metav1.SetMetaDataAnnotation(&worker.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)
+
+	return reconcile.Result{}, r.client.Patch(ctx, worker, patch)
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func Test_networkAccessMigrationReconciler(t *testing.T) {
+	const namespace = "shoot--project--name"
+
+	var (
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+		worker = func(conditionStatus gardencorev1beta1.ConditionStatus, state gardencorev1beta1.LastOperationState, lastUpdate time.Time) *extensionsv1alpha1.Worker {
+			w := &extensionsv1alpha1.Worker{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "name"},
+				Spec: extensionsv1alpha1.WorkerSpec{
+					DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: metal.Type},
+				},
+			}
+			if conditionStatus != "" {
+				w.Status.Conditions = []gardencorev1beta1.Condition{{Type: ConditionTypeNetworkAccessMigration, Status: conditionStatus}}
+			}
+			if state != "" {
+				w.Status.LastOperation = &gardencorev1beta1.LastOperation{State: state, LastUpdateTime: metav1.NewTime(lastUpdate)}
+			}
+			return w
+		}
+	)
+
+	tests := []struct {
+		name           string
+		worker         *extensionsv1alpha1.Worker
+		wantResult     reconcile.Result
+		wantReconciled bool
+	}{
+		{
+			name:   "worker without migration is skipped",
+			worker: worker("", gardencorev1beta1.LastOperationStateSucceeded, now.Add(-time.Hour)),
+		},
+		{
+			name:   "completed migration is skipped",
+			worker: worker(gardencorev1beta1.ConditionTrue, gardencorev1beta1.LastOperationStateSucceeded, now.Add(-time.Hour)),
+		},
+		{
+			name:   "failed reconciliation is left to the worker controller",
+			worker: worker(gardencorev1beta1.ConditionProgressing, gardencorev1beta1.LastOperationStateError, now.Add(-time.Hour)),
+		},
+		{
+			name:       "pending migration is requeued until the requeue period has passed",
+			worker:     worker(gardencorev1beta1.ConditionProgressing, gardencorev1beta1.LastOperationStateSucceeded, now.Add(-20*time.Second)),
+			wantResult: reconcile.Result{RequeueAfter: 40 * time.Second},
+		},
+		{
+			name:           "pending migration triggers a reconciliation of the worker",
+			worker:         worker(gardencorev1beta1.ConditionProgressing, gardencorev1beta1.LastOperationStateSucceeded, now.Add(-2*time.Minute)),
+			wantReconciled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := extensionsv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(tt.worker).Build()
+
+			r := &networkAccessMigrationReconciler{
+				client: c,
+				clock:  clocktesting.NewFakeClock(now),
+			}
+
+			ctx := context.Background()
+			got, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tt.worker)})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.wantResult, got); diff != "" {
+				t.Errorf("result diff (+got -want):\n %s", diff)
+			}
+
+			worker := &extensionsv1alpha1.Worker{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(tt.worker), worker); err != nil {
+				t.Fatal(err)
+			}
+			if got := worker.Annotations[v1beta1constants.GardenerOperation] == v1beta1constants.GardenerOperationReconcile; got != tt.wantReconciled {
+				t.Errorf("worker reconciled = %v, want %v", got, tt.wantReconciled)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fcmv2 "github.com/metal-stack/firewall-controller-manager/api/v2"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_networkAccessTypeFromFirewallDeployment(t *testing.T) {
+	tests := []struct {
+		name string
+		spec fcmv2.FirewallSpec
+		want apismetal.NetworkAccessType
+	}{
+		{
+			name: "baseline",
+			spec: fcmv2.FirewallSpec{},
+			want: apismetal.NetworkAccessBaseline,
+		},
+		{
+			name: "restricted",
+			spec: fcmv2.FirewallSpec{
+				DNSServerAddress: "1.1.1.1",
+			},
+			want: apismetal.NetworkAccessRestricted,
+		},
+		{
+			name: "forbidden",
+			spec: fcmv2.FirewallSpec{
+				DNSServerAddress: "1.1.1.1",
+				AllowedNetworks: fcmv2.AllowedNetworks{
+					Egress: []string{"10.0.0.0/24"},
+				},
+			},
+			want: apismetal.NetworkAccessForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploy := &fcmv2.FirewallDeployment{
+				Spec: fcmv2.FirewallDeploymentSpec{
+					Template: fcmv2.FirewallTemplateSpec{
+						Spec: tt.spec,
+					},
+				},
+			}
+
+			if got := networkAccessTypeFromFirewallDeployment(deploy); got != tt.want {
+				t.Errorf("networkAccessTypeFromFirewallDeployment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadBalancerIPsOutsideOf(t *testing.T) {
+	loadBalancer := func(ips ...string) corev1.Service {
+		svc := corev1.Service{
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+			},
+		}
+		for _, ip := range ips {
+			svc.Status.LoadBalancer.Ingress = append(svc.Status.LoadBalancer.Ingress, corev1.LoadBalancerIngress{IP: ip})
+		}
+		return svc
+	}
+
+	tests := []struct {
+		name     string
+		services []corev1.Service
+		cidrs    []string
+		want     []string
+	}{
+		{
+			name:     "all ips contained",
+			services: []corev1.Service{loadBalancer("10.0.0.1"), loadBalancer("10.1.0.1", "2001:db8::1")},
+			cidrs:    []string{"10.0.0.0/16", "10.1.0.0/16", "2001:db8::/32"},
+			want:     nil,
+		},
+		{
+			name:     "ips outside of the cidrs are returned",
+			services: []corev1.Service{loadBalancer("10.0.0.1"), loadBalancer("10.2.0.1")},
+			cidrs:    []string{"10.0.0.0/16"},
+			want:     []string{"10.2.0.1"},
+		},
+		{
+			name: "other service types are ignored",
+			services: []corev1.Service{
+				{
+					Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+					Status: corev1.ServiceStatus{
+						LoadBalancer: corev1.LoadBalancerStatus{
+							Ingress: []corev1.LoadBalancerIngress{{IP: "10.2.0.1"}},
+						},
+					},
+				},
+			},
+			cidrs: []string{"10.0.0.0/16"},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := loadBalancerIPsOutsideOf(tt.services, tt.cidrs)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}
@@ -2,15 +2,20 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
 	metalgo "github.com/metal-stack/metal-go"
 	"github.com/metal-stack/metal-go/api/client/firewall"
 	metalip "github.com/metal-stack/metal-go/api/client/ip"
 	"github.com/metal-stack/metal-go/api/client/network"
+	"github.com/metal-stack/metal-go/api/client/project"
+	"github.com/metal-stack/metal-go/api/client/user"
 	"github.com/metal-stack/metal-go/api/models"
 	"github.com/metal-stack/metal-lib/pkg/tag"
 
@@ -28,13 +33,46 @@ func NewClient(ctx context.Context, k8sClient client.Client, endpoint string, se
 	return NewClientFromCredentials(endpoint, credentials)
 }
 
-// NewClientFromCredentials returns a new metal client with the client constructed from the given credentials.
+// NewClientFromCredentials returns a metal client for the given endpoint and credentials. Clients are pooled by
+// endpoint and credentials, such that all reconciliations against the same metal-api share the rate limit and the
+// caches of a client. The requests of the client are recorded in the metrics of the extension.
 func NewClientFromCredentials(endpoint string, credentials *metal.Credentials) (metalgo.Client, error) {
-	client, err := metalgo.NewDriver(endpoint, credentials.MetalAPIKey, credentials.MetalAPIHMac)
+	return defaultPool.get(endpoint, credentials)
+}
+
+// VerifyCredentials checks that the client authenticates against the metal-api and that it has access to the given project.
+func VerifyCredentials(ctx context.Context, client metalgo.Client, projectID string) error {
+	_, err := client.Project().FindProject(project.NewFindProjectParams().WithID(projectID).WithContext(ctx), nil)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("unable to look up project %q with the given credentials: %w", projectID, err)
+	}
+
+	return nil
+}
+
+// VerifyProjectOwnership checks that the given project can be looked up with the client and that it belongs to the
+// given tenant.
+func VerifyProjectOwnership(ctx context.Context, client metalgo.Client, projectID, tenant string) error {
+	resp, err := client.Project().FindProject(project.NewFindProjectParams().WithID(projectID).WithContext(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("unable to look up project %q with the given credentials: %w", projectID, err)
+	}
+
+	if resp.Payload == nil || resp.Payload.TenantID != tenant {
+		return fmt.Errorf("project %q does not belong to tenant %q", projectID, tenant)
+	}
+
+	return nil
+}
+
+// VerifyAuthentication checks that the client authenticates against the metal-api.
+func VerifyAuthentication(ctx context.Context, client metalgo.Client) error {
+	_, err := client.User().GetMe(user.NewGetMeParams().WithContext(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("unable to authenticate with the given credentials: %w", err)
 	}
-	return client, nil
+
+	return nil
 }
 
 // ReadCredentialsFromSecretRef returns metal credentials from the provider credentials from a given secret reference.
@@ -52,6 +90,111 @@ func ReadCredentialsFromSecretRef(ctx context.Context, k8sClient client.Client,
 	return credentials, nil
 }
 
+// DefaultExternalNetwork returns the network of the given firewall networks from which the cloud-controller-manager
+// allocates ips for services of type load balancer by default. Networks which carry the default external network tag
+// and are not derived from a private super network have precedence. An empty string is returned if none of the
+// firewall networks qualifies.
+func DefaultExternalNetwork(networks map[string]*models.V1NetworkResponse, firewallNetworks []string) (string, error) {
+	var (
+		externalNetworks []*models.V1NetworkResponse
+		dmzNetworks      []*models.V1NetworkResponse // dmzNetworks are deprecated, this can be removed after all users had enough time to migrate to isolated clusters
+	)
+
+	for _, networkID := range firewallNetworks {
+		nw, ok := networks[networkID]
+		if !ok {
+			return "", fmt.Errorf("network defined in firewall networks does not exist in metal-api")
+		}
+
+		_, ok = nw.Labels[tag.NetworkDefaultExternal]
+		if !ok {
+			continue
+		}
+
+		if nw.Parentnetworkid == "" {
+			externalNetworks = append(externalNetworks, nw)
+			continue
+		}
+
+		parent, ok := networks[nw.Parentnetworkid]
+		if !ok {
+			return "", fmt.Errorf("network defined in firewall networks specified a parent network that does not exist in metal-api")
+		}
+
+		if *parent.Privatesuper {
+			dmzNetworks = append(dmzNetworks, nw)
+			continue
+		}
+	}
+
+	// if there is an external network we prefer this over DMZ networks
+	// from the external network we prefer the one that is the default
+	// if there are multiple external networks it's impossible to distinguish which one to choose, so we use the first one defined in the list
+	if len(externalNetworks) != 0 {
+		for _, nw := range externalNetworks {
+			if _, ok := nw.Labels[tag.NetworkDefault]; ok {
+				return *nw.ID, nil
+			}
+		}
+
+		return *externalNetworks[0].ID, nil
+	}
+
+	if len(dmzNetworks) != 0 {
+		// if there are multiple dmz networks it's impossible to distinguish which one to choose, so we use the first one defined in the list
+		return *dmzNetworks[0].ID, nil
+	}
+
+	return "", nil
+}
+
+// ErrWorkloadIdentity is returned when credentials are read from a credentials binding which references a workload
+// identity. The tokens of workload identities are only issued into the shoot namespace of the seed, so they cannot
+// be used outside of the seed, e.g. during admission.
+var ErrWorkloadIdentity = errors.New("credentials binding references a workload identity whose tokens are only issued into the seed")
+
+// ReadCredentialsFromBinding returns the metal-api credentials referenced by the given credentials or secret binding
+// of a shoot. ErrWorkloadIdentity is returned if the credentials binding references a workload identity.
+func ReadCredentialsFromBinding(ctx context.Context, reader client.Reader, namespace string, credentialsBindingName, secretBindingName *string) (*metal.Credentials, error) {
+	var secretKey client.ObjectKey
+
+	switch {
+	case credentialsBindingName != nil:
+		credentialsBinding := &securityv1alpha1.CredentialsBinding{}
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *credentialsBindingName}, credentialsBinding); err != nil {
+			return nil, err
+		}
+
+		ref := credentialsBinding.CredentialsRef
+		if ref.APIVersion == securityv1alpha1.SchemeGroupVersion.String() && ref.Kind == "WorkloadIdentity" {
+			return nil, ErrWorkloadIdentity
+		}
+		if ref.APIVersion != corev1.SchemeGroupVersion.String() || ref.Kind != "Secret" {
+			return nil, fmt.Errorf("unsupported credentials reference: version %q, kind %q", ref.APIVersion, ref.Kind)
+		}
+
+		secretKey = client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+
+	case secretBindingName != nil:
+		secretBinding := &gardencorev1beta1.SecretBinding{} //nolint:staticcheck
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *secretBindingName}, secretBinding); err != nil {
+			return nil, err
+		}
+
+		secretKey = client.ObjectKey{Namespace: secretBinding.SecretRef.Namespace, Name: secretBinding.SecretRef.Name}
+
+	default:
+		return nil, fmt.Errorf("shoot does not reference a credentials or secret binding")
+	}
+
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, secretKey, secret); err != nil {
+		return nil, err
+	}
+
+	return metal.ReadCredentialsSecret(secret)
+}
+
 // GetPrivateNetworksFromNodeNetwork returns the private network that belongs to the given node network cidr and project.
 func GetPrivateNetworksFromNodeNetwork(ctx context.Context, client metalgo.Client, projectID string, nodeNetworkCIDR string) ([]*models.V1NetworkResponse, error) {
 	if nodeNetworkCIDR == "" {
@@ -135,6 +278,28 @@ func UpdateIPInCluster(ctx context.Context, client metalgo.Client, ip *models.V1
 	return nil
 }
 
+// RemoveClusterTagsFromIP updates the IP such that no tags remain which reference the given cluster
+func RemoveClusterTagsFromIP(ctx context.Context, client metalgo.Client, ip *models.V1IPResponse, clusterID string) error {
+	var newTags []string
+	for _, t := range ip.Tags {
+		_, value, _ := strings.Cut(t, "=")
+		if strings.HasPrefix(value, clusterID) {
+			continue
+		}
+		newTags = append(newTags, t)
+	}
+
+	_, err := client.IP().UpdateIP(metalip.NewUpdateIPParams().WithBody(&models.V1IPUpdateRequest{
+		Ipaddress: ip.Ipaddress,
+		Tags:      newTags,
+	}).WithContext(ctx), nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func isMemberOfCluster(t, clusterID string) bool {
 	if strings.HasPrefix(t, tag.ClusterID) {
 		parts := strings.Split(t, "=")
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	openapiruntime "github.com/go-openapi/runtime"
+	metalgo "github.com/metal-stack/metal-go"
+	"github.com/metal-stack/metal-go/api/client/project"
+	"github.com/metal-stack/metal-go/api/models"
+)
+
+type fakeProjectDriver struct {
+	metalgo.Client
+	projects *fakeProjectClient
+}
+
+func (d *fakeProjectDriver) Project() project.ClientService {
+	return d.projects
+}
+
+type fakeProjectClient struct {
+	project.ClientService
+	projects map[string]*models.V1ProjectResponse
+	lookups  int
+}
+
+func (c *fakeProjectClient) FindProject(params *project.FindProjectParams, _ openapiruntime.ClientAuthInfoWriter, _ ...project.ClientOption) (*project.FindProjectOK, error) {
+	c.lookups++
+
+	p, ok := c.projects[params.ID]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+
+	return &project.FindProjectOK{Payload: p}, nil
+}
+
+func TestVerifyProjectOwnership(t *testing.T) {
+	projects := &fakeProjectClient{
+		projects: map[string]*models.V1ProjectResponse{
+			"project-a": {TenantID: "tenant-a"},
+		},
+	}
+	client := newInstrumentedClient(&fakeProjectDriver{projects: projects}, "https://metal-api")
+
+	tests := []struct {
+		name      string
+		projectID string
+		tenant    string
+		wantErr   bool
+	}{
+		{
+			name:      "project belongs to the tenant",
+			projectID: "project-a",
+			tenant:    "tenant-a",
+		},
+		{
+			name:      "project belongs to another tenant",
+			projectID: "project-a",
+			tenant:    "tenant-b",
+			wantErr:   true,
+		},
+		{
+			name:      "project does not exist",
+			projectID: "project-b",
+			tenant:    "tenant-a",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyProjectOwnership(context.Background(), client, tt.projectID, tt.tenant)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyProjectOwnership() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// projects are not cached, such that a revoked access is noticed right away
+	if projects.lookups != 3 {
+		t.Errorf("expected 3 lookups against the metal-api, got %d", projects.lookups)
+	}
+}
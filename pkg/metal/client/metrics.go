@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	openapiruntime "github.com/go-openapi/runtime"
+	metalgo "github.com/metal-stack/metal-go"
+	metalip "github.com/metal-stack/metal-go/api/client/ip"
+	"github.com/metal-stack/metal-go/api/client/network"
+	"github.com/metal-stack/metal-lib/pkg/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "provider_metal"
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "metal_api",
+		Name:      "requests_total",
+		Help:      "Total number of requests sent to the metal-api by endpoint, operation and response code.",
+	}, []string{"endpoint", "operation", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "metal_api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the requests sent to the metal-api by endpoint and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint", "operation"})
+
+	networkAllocationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "metal_api",
+		Name:      "network_allocations_total",
+		Help:      "Total number of networks allocated in the metal-api by endpoint.",
+	}, []string{"endpoint"})
+
+	ipFreesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "metal_api",
+		Name:      "ip_frees_total",
+		Help:      "Total number of ips freed in the metal-api by endpoint.",
+	}, []string{"endpoint"})
+
+	// FirewallDeploymentReconcilesTotal counts the reconciliations of firewall deployments by metal-api endpoint and result.
+	FirewallDeploymentReconcilesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "firewall_deployment_reconciles_total",
+		Help:      "Total number of firewall deployment reconciliations by metal-api endpoint and result.",
+	}, []string{"endpoint", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		requestsTotal,
+		requestDuration,
+		networkAllocationsTotal,
+		ipFreesTotal,
+		FirewallDeploymentReconcilesTotal,
+	)
+}
+
+// instrumentedTransport is the transport of all services of a pooled client. it rate limits the operations sent to
+// the metal-api, records their metrics and retries idempotent operations which failed with a transient error.
+type instrumentedTransport struct {
+	openapiruntime.ClientTransport
+	endpoint string
+	limiter  *rate.Limiter
+}
+
+// Submit sends the given operation to the metal-api.
+func (t *instrumentedTransport) Submit(op *openapiruntime.ClientOperation) (any, error) {
+	ctx := op.Context //nolint:staticcheck // the generated clients pass the context of the params in the operation
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var resp any
+	request := func() error {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = observe(t.endpoint, op.ID, func() (any, error) {
+			return t.ClientTransport.Submit(op)
+		})
+		return err
+	}
+
+	if !isIdempotent(op) {
+		return resp, request()
+	}
+
+	return resp, retry.OnError(requestBackoff, isRetriable, request)
+}
+
+// isIdempotent returns true if the given operation only reads from the metal-api. searches are sent as post requests
+// by the metal-api, so they are identified by their operation id.
+func isIdempotent(op *openapiruntime.ClientOperation) bool {
+	return op.Method == http.MethodGet || strings.HasPrefix(op.ID, "find") || strings.HasPrefix(op.ID, "list")
+}
+
+// instrumentedClient caches the metal-api operations which are requested by most of the reconciliations and records
+// the allocations and releases of resources. the requests themselves are instrumented by the instrumentedTransport.
+// images are not cached as the extension resolves them from the cloud profile and never looks them up in the
+// metal-api. projects are not cached either, as their lookup verifies that the credentials of a shoot grant access
+// to its project, which must not outlive a revocation of the access.
+type instrumentedClient struct {
+	metalgo.Client
+	endpoint string
+
+	// networks caches the listing of all networks, which is requested by most of the reconciliations.
+	networks *cache.Cache[string, *network.ListNetworksOK]
+	// networksChanged is set when a network was allocated or freed through this client, such that the next listing
+	// bypasses the cache.
+	networksChanged atomic.Bool
+}
+
+func newInstrumentedClient(client metalgo.Client, endpoint string) *instrumentedClient {
+	c := &instrumentedClient{
+		Client:   client,
+		endpoint: endpoint,
+	}
+
+	c.networks = cache.New(networkCacheExpiration, func(ctx context.Context, _ string) (*network.ListNetworksOK, error) {
+		return c.Client.Network().ListNetworks(network.NewListNetworksParams().WithContext(ctx), nil)
+	})
+
+	return c
+}
+
+func (c *instrumentedClient) IP() metalip.ClientService {
+	return &instrumentedIPClient{ClientService: c.Client.IP(), client: c}
+}
+
+func (c *instrumentedClient) Network() network.ClientService {
+	return &instrumentedNetworkClient{ClientService: c.Client.Network(), client: c}
+}
+
+type instrumentedIPClient struct {
+	metalip.ClientService
+	client *instrumentedClient
+}
+
+func (c *instrumentedIPClient) FreeIP(params *metalip.FreeIPParams, authInfo openapiruntime.ClientAuthInfoWriter, opts ...metalip.ClientOption) (*metalip.FreeIPOK, error) {
+	resp, err := c.ClientService.FreeIP(params, authInfo, opts...)
+	if err == nil {
+		ipFreesTotal.WithLabelValues(c.client.endpoint).Inc()
+	}
+	return resp, err
+}
+
+type instrumentedNetworkClient struct {
+	network.ClientService
+	client *instrumentedClient
+}
+
+func (c *instrumentedNetworkClient) AllocateNetwork(params *network.AllocateNetworkParams, authInfo openapiruntime.ClientAuthInfoWriter, opts ...network.ClientOption) (*network.AllocateNetworkCreated, error) {
+	resp, err := c.ClientService.AllocateNetwork(params, authInfo, opts...)
+	if err == nil {
+		networkAllocationsTotal.WithLabelValues(c.client.endpoint).Inc()
+		c.client.networksChanged.Store(true)
+	}
+	return resp, err
+}
+
+func (c *instrumentedNetworkClient) FreeNetwork(params *network.FreeNetworkParams, authInfo openapiruntime.ClientAuthInfoWriter, opts ...network.ClientOption) (*network.FreeNetworkOK, error) {
+	resp, err := c.ClientService.FreeNetwork(params, authInfo, opts...)
+	if err == nil {
+		c.client.networksChanged.Store(true)
+	}
+	return resp, err
+}
+
+// ListNetworks serves the listing of all networks from the cache of the client. The response is shared between
+// the callers and must not be modified. Networks changed through other clients are listed with a delay of up to
+// networkCacheExpiration.
+func (c *instrumentedNetworkClient) ListNetworks(params *network.ListNetworksParams, authInfo openapiruntime.ClientAuthInfoWriter, opts ...network.ClientOption) (*network.ListNetworksOK, error) {
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if c.client.networksChanged.Swap(false) {
+		return c.client.networks.Refresh(ctx, c.client.endpoint)
+	}
+
+	return c.client.networks.Get(ctx, c.client.endpoint)
+}
+
+// observe records the duration and the response code of the given metal-api operation.
+func observe[T any](endpoint, operation string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	resp, err := fn()
+
+	requestDuration.WithLabelValues(endpoint, operation).Observe(time.Since(start).Seconds())
+	requestsTotal.WithLabelValues(endpoint, operation, responseCode(err)).Inc()
+
+	return resp, err
+}
+
+// responseCode returns the http status code of a metal-api error. successful responses are recorded as 2xx as the
+// concrete status code is only known to the generated response types.
+func responseCode(err error) string {
+	if err == nil {
+		return "2xx"
+	}
+
+	if code, ok := statusCode(err); ok {
+		return strconv.Itoa(code)
+	}
+
+	return "error"
+}
+
+// statusCode returns the http status code of a metal-api error if the error contains one.
+func statusCode(err error) (int, bool) {
+	var coder interface{ Code() int }
+	if errors.As(err, &coder) {
+		return coder.Code(), true
+	}
+
+	var apiErr *openapiruntime.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code, true
+	}
+
+	return 0, false
+}
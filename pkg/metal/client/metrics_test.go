@@ -0,0 +1,128 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	openapiruntime "github.com/go-openapi/runtime"
+)
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("status %d", e.code)
+}
+
+func (e *codeError) Code() int {
+	return e.code
+}
+
+func Test_responseCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "success",
+			err:  nil,
+			want: "2xx",
+		},
+		{
+			name: "generated error response",
+			err:  fmt.Errorf("wrapped: %w", &codeError{code: 409}),
+			want: "409",
+		},
+		{
+			name: "unexpected api response",
+			err:  openapiruntime.NewAPIError("findNetworks", nil, 502),
+			want: "502",
+		},
+		{
+			name: "transport error",
+			err:  errors.New("connection refused"),
+			want: "error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := responseCode(tt.err); got != tt.want {
+				t.Errorf("responseCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeTransport struct {
+	errs     []error
+	requests int
+}
+
+func (t *fakeTransport) Submit(*openapiruntime.ClientOperation) (any, error) {
+	t.requests++
+	if len(t.errs) == 0 {
+		return "ok", nil
+	}
+	err := t.errs[0]
+	t.errs = t.errs[1:]
+	return nil, err
+}
+
+func Test_instrumentedTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		op           *openapiruntime.ClientOperation
+		errs         []error
+		wantErr      bool
+		wantRequests int
+	}{
+		{
+			name:         "search is retried on a server error",
+			op:           &openapiruntime.ClientOperation{ID: "findIPs", Method: http.MethodPost},
+			errs:         []error{openapiruntime.NewAPIError("findIPs", nil, 503)},
+			wantRequests: 2,
+		},
+		{
+			name:         "get is retried on a server error",
+			op:           &openapiruntime.ClientOperation{ID: "getMe", Method: http.MethodGet},
+			errs:         []error{openapiruntime.NewAPIError("getMe", nil, 503)},
+			wantRequests: 2,
+		},
+		{
+			name:         "allocation is not retried",
+			op:           &openapiruntime.ClientOperation{ID: "allocateIP", Method: http.MethodPost},
+			errs:         []error{openapiruntime.NewAPIError("allocateIP", nil, 503)},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "client error is not retried",
+			op:           &openapiruntime.ClientOperation{ID: "findIPs", Method: http.MethodPost},
+			errs:         []error{&codeError{code: 404}},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeTransport{errs: tt.errs}
+			transport := &instrumentedTransport{
+				ClientTransport: fake,
+				endpoint:        "https://metal-api",
+				limiter:         newLimiter(),
+			}
+
+			_, err := transport.Submit(tt.op)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Submit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fake.requests != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, fake.requests)
+			}
+		})
+	}
+}
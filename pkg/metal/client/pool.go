@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+
+	openapiruntime "github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	metalgo "github.com/metal-stack/metal-go"
+	"github.com/metal-stack/security"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// clientQPS is the sustained rate of requests all pooled clients of an endpoint send to the metal-api.
+	clientQPS = 20
+	// clientBurst is the amount of requests all pooled clients of an endpoint may send to the metal-api at once.
+	clientBurst = 40
+	// networkCacheExpiration is the duration for which network listings are served from the cache. A client only
+	// bypasses the cache after networks were allocated or freed through itself, so networks which are changed through
+	// other clients, e.g. with other credentials or by other replicas, are listed with a delay of up to this duration.
+	networkCacheExpiration = 30 * time.Second
+	// hmacAuthType is the type of the hmac authentication of the metal-api, which metal-go uses by default.
+	hmacAuthType = "Metal-Admin"
+	// clientIdleTimeout is the duration after which unused clients are removed from the pool, e.g. after the
+	// credentials were rotated.
+	clientIdleTimeout = 1 * time.Hour
+)
+
+var (
+	defaultPool = newClientPool(newDriver)
+
+	// requestBackoff is used to retry idempotent requests which failed with a transient error.
+	requestBackoff = wait.Backoff{
+		Steps:    4,
+		Duration: 200 * time.Millisecond,
+		Factor:   2.0,
+		Jitter:   0.1,
+	}
+)
+
+type (
+	driverFunc func(endpoint, apiKey, hmac string, limiter *rate.Limiter) (metalgo.Client, error)
+
+	poolKey struct {
+		endpoint    string
+		credentials string
+	}
+
+	// transportSetter is implemented by all services of the generated metal-api client.
+	transportSetter interface {
+		SetTransport(transport openapiruntime.ClientTransport)
+	}
+
+	pooledClient struct {
+		client   metalgo.Client
+		lastUsed time.Time
+	}
+
+	// clientPool shares the metal-api clients of all controllers and control planes by endpoint and credentials, such
+	// that the caches of a client apply to all reconciliations against the same metal-api. The rate limit is shared by
+	// all clients of an endpoint, regardless of their credentials.
+	clientPool struct {
+		newDriver driverFunc
+
+		mu       sync.Mutex
+		clients  map[poolKey]*pooledClient
+		limiters map[string]*rate.Limiter
+	}
+)
+
+func newClientPool(newDriver driverFunc) *clientPool {
+	return &clientPool{
+		newDriver: newDriver,
+		clients:   map[poolKey]*pooledClient{},
+		limiters:  map[string]*rate.Limiter{},
+	}
+}
+
+// get returns the pooled client for the given endpoint and credentials and creates it if it does not exist yet.
+func (p *clientPool) get(endpoint string, credentials *metal.Credentials) (metalgo.Client, error) {
+	key := poolKey{
+		endpoint:    endpoint,
+		credentials: credentialsHash(credentials),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	p.prune(now)
+
+	if c, ok := p.clients[key]; ok {
+		c.lastUsed = now
+		return c.client, nil
+	}
+
+	limiter, ok := p.limiters[endpoint]
+	if !ok {
+		limiter = newLimiter()
+		p.limiters[endpoint] = limiter
+	}
+
+	driver, err := p.newDriver(endpoint, credentials.MetalAPIKey, credentials.MetalAPIHMac, limiter)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newInstrumentedClient(driver, endpoint)
+	p.clients[key] = &pooledClient{client: c, lastUsed: now}
+
+	return c, nil
+}
+
+// prune removes the clients which were not used within the idle timeout. this prevents the pool from growing
+// with every rotation of the credentials, which happens regularly for tokens of workload identities.
+func (p *clientPool) prune(now time.Time) {
+	for key, c := range p.clients {
+		if now.Sub(c.lastUsed) > clientIdleTimeout {
+			delete(p.clients, key)
+		}
+	}
+}
+
+// credentialsHash returns a hash of the given credentials, such that the pool does not keep the plain credentials
+// in its keys.
+func credentialsHash(credentials *metal.Credentials) string {
+	h := sha256.New()
+	h.Write([]byte(credentials.MetalAPIKey))
+	h.Write([]byte{0})
+	h.Write([]byte(credentials.MetalAPIHMac))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newDriver returns a metal-go driver which sends the operations of all its services through an instrumentedTransport.
+// metal-go does not allow to inject a transport into the driver, so the transport is built with the same
+// authentication as the driver and set on every service of the client interface. the services are shared by all
+// callers of the driver, such that no operation bypasses the transport.
+func newDriver(endpoint, apiKey, hmac string, limiter *rate.Limiter) (metalgo.Client, error) {
+	driver, err := metalgo.NewDriver(endpoint, apiKey, hmac)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	rt := httptransport.New(u.Host, u.Path, []string{u.Scheme})
+	switch {
+	case hmac != "":
+		auth := security.NewHMACAuth(hmacAuthType, []byte(hmac))
+		rt.DefaultAuthentication = openapiruntime.ClientAuthInfoWriterFunc(func(rq openapiruntime.ClientRequest, _ strfmt.Registry) error {
+			auth.AddAuthToClientRequest(rq, time.Now())
+			return nil
+		})
+	case apiKey != "":
+		rt.DefaultAuthentication = httptransport.BearerToken(apiKey)
+	}
+
+	transport := &instrumentedTransport{
+		ClientTransport: rt,
+		endpoint:        endpoint,
+		limiter:         limiter,
+	}
+
+	v := reflect.ValueOf(driver)
+	services := reflect.TypeFor[metalgo.Client]()
+	for i := range services.NumMethod() {
+		m := services.Method(i)
+		if m.Type.NumIn() != 0 || m.Type.NumOut() != 1 {
+			continue
+		}
+
+		if service, ok := v.MethodByName(m.Name).Call(nil)[0].Interface().(transportSetter); ok {
+			service.SetTransport(transport)
+		}
+	}
+
+	return driver, nil
+}
+
+// isRetriable returns true if the given error of a metal-api request is transient, i.e. a transport error, a
+// rate limited or a server side error.
+func isRetriable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	code, ok := statusCode(err)
+	if !ok {
+		return false
+	}
+
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+func newLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(clientQPS), clientBurst)
+}
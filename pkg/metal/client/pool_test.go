@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	openapiruntime "github.com/go-openapi/runtime"
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+	metalgo "github.com/metal-stack/metal-go"
+	"golang.org/x/time/rate"
+)
+
+type fakeDriver struct {
+	metalgo.Client
+}
+
+func Test_clientPool(t *testing.T) {
+	limiters := map[metalgo.Client]*rate.Limiter{}
+	pool := newClientPool(func(endpoint, apiKey, hmac string, limiter *rate.Limiter) (metalgo.Client, error) {
+		if endpoint == "" {
+			return nil, errors.New("endpoint must not be empty")
+		}
+		driver := &fakeDriver{}
+		limiters[driver] = limiter
+		return driver, nil
+	})
+
+	a, err := pool.get("https://metal-api-a", &metal.Credentials{MetalAPIHMac: "hmac"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	again, err := pool.get("https://metal-api-a", &metal.Credentials{MetalAPIHMac: "hmac"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a != again {
+		t.Errorf("expected the pooled client to be reused for the same endpoint and credentials")
+	}
+
+	otherCredentials, err := pool.get("https://metal-api-a", &metal.Credentials{MetalAPIHMac: "other"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a == otherCredentials {
+		t.Errorf("expected a distinct client for different credentials")
+	}
+
+	otherEndpoint, err := pool.get("https://metal-api-b", &metal.Credentials{MetalAPIHMac: "hmac"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a == otherEndpoint {
+		t.Errorf("expected a distinct client for a different endpoint")
+	}
+
+	if _, err := pool.get("", &metal.Credentials{MetalAPIHMac: "hmac"}); err == nil {
+		t.Errorf("expected an error when the driver cannot be created")
+	}
+
+	if len(limiters) != 3 {
+		t.Errorf("expected 3 drivers to be created, got %d", len(limiters))
+	}
+
+	limiter := func(c metalgo.Client) *rate.Limiter {
+		return limiters[c.(*instrumentedClient).Client]
+	}
+	if limiter(a) != limiter(otherCredentials) {
+		t.Errorf("expected the rate limit to be shared by the clients of an endpoint")
+	}
+	if limiter(a) == limiter(otherEndpoint) {
+		t.Errorf("expected a distinct rate limit for a different endpoint")
+	}
+}
+
+func Test_credentialsHash(t *testing.T) {
+	if credentialsHash(&metal.Credentials{MetalAPIKey: "a", MetalAPIHMac: "b"}) == credentialsHash(&metal.Credentials{MetalAPIKey: "ab"}) {
+		t.Errorf("expected the hash to distinguish the api key from the hmac")
+	}
+}
+
+func Test_isRetriable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "transport error",
+			err:  &url.Error{Op: "Get", URL: "https://metal-api", Err: errors.New("connection refused")},
+			want: true,
+		},
+		{
+			name: "too many requests",
+			err:  fmt.Errorf("wrapped: %w", &codeError{code: 429}),
+			want: true,
+		},
+		{
+			name: "server error",
+			err:  openapiruntime.NewAPIError("listNetworks", nil, 503),
+			want: true,
+		},
+		{
+			name: "client error",
+			err:  &codeError{code: 404},
+			want: false,
+		},
+		{
+			name: "context canceled",
+			err:  &url.Error{Op: "Get", URL: "https://metal-api", Err: context.Canceled},
+			want: false,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("rate: Wait(n=1) would exceed context deadline"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetriable(tt.err); got != tt.want {
+				t.Errorf("isRetriable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_clientPool_prune(t *testing.T) {
+	pool := newClientPool(func(endpoint, apiKey, hmac string, limiter *rate.Limiter) (metalgo.Client, error) {
+		return &fakeDriver{}, nil
+	})
+
+	rotated, err := pool.get("https://metal-api", &metal.Credentials{MetalAPIKey: "token-1", WorkloadIdentity: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range pool.clients {
+		c.lastUsed = time.Now().Add(-2 * clientIdleTimeout)
+	}
+
+	current, err := pool.get("https://metal-api", &metal.Credentials{MetalAPIKey: "token-2", WorkloadIdentity: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pool.clients) != 1 {
+		t.Errorf("expected the idle client to be removed from the pool, got %d clients", len(pool.clients))
+	}
+	if rotated == current {
+		t.Errorf("expected a distinct client for the rotated token")
+	}
+}
@@ -3,11 +3,24 @@ package metal
 import (
 	"fmt"
 
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
 	corev1 "k8s.io/api/core/v1"
 )
 
 // ReadCredentialsSecret reads a secret containing credentials.
 func ReadCredentialsSecret(secret *corev1.Secret) (*Credentials, error) {
+	if IsWorkloadIdentitySecret(secret) {
+		token := secret.Data[securityv1alpha1constants.DataKeyToken]
+		if len(token) == 0 {
+			return nil, fmt.Errorf("workload identity token has not been issued yet")
+		}
+
+		return &Credentials{
+			MetalAPIKey:      string(token),
+			WorkloadIdentity: true,
+		}, nil
+	}
+
 	if secret.Data == nil {
 		return nil, fmt.Errorf("secret does not contain any data")
 	}
@@ -17,3 +30,9 @@ func ReadCredentialsSecret(secret *corev1.Secret) (*Credentials, error) {
 		MetalAPIKey:  string(secret.Data[APIKey]),
 	}, nil
 }
+
+// IsWorkloadIdentitySecret returns true if the given secret contains a token which gardener requests for a
+// workload identity.
+func IsWorkloadIdentitySecret(secret *corev1.Secret) bool {
+	return secret.Labels[securityv1alpha1constants.LabelPurpose] == securityv1alpha1constants.LabelPurposeWorkloadIdentityTokenRequestor
+}
@@ -0,0 +1,59 @@
+package metal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
+)
+
+var _ = Describe("Secret", func() {
+	Describe("#ReadCredentialsSecret", func() {
+		It("should read the hmac and the api key", func() {
+			credentials, err := metal.ReadCredentialsSecret(&corev1.Secret{
+				Data: map[string][]byte{
+					metal.APIHMac: []byte("hmac"),
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credentials).To(Equal(&metal.Credentials{MetalAPIHMac: "hmac"}))
+		})
+
+		It("should fail if the secret does not contain any data", func() {
+			_, err := metal.ReadCredentialsSecret(&corev1.Secret{})
+
+			Expect(err).To(MatchError("secret does not contain any data"))
+		})
+
+		It("should read the token of a workload identity", func() {
+			credentials, err := metal.ReadCredentialsSecret(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"security.gardener.cloud/purpose": "workload-identity-token-requestor",
+					},
+				},
+				Data: map[string][]byte{
+					"token": []byte("a-token"),
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credentials).To(Equal(&metal.Credentials{MetalAPIKey: "a-token", WorkloadIdentity: true}))
+		})
+
+		It("should fail if the token of a workload identity was not issued yet", func() {
+			_, err := metal.ReadCredentialsSecret(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"security.gardener.cloud/purpose": "workload-identity-token-requestor",
+					},
+				},
+			})
+
+			Expect(err).To(MatchError("workload identity token has not been issued yet"))
+		})
+	})
+})
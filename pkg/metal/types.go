@@ -55,6 +55,10 @@ const (
 	FirewallControllerManagerDeploymentName = "firewall-controller-manager"
 	// FirewallDeploymentName is the name of the firewall deployment deployed to the seed cluster to get managed by the FCM.
 	FirewallDeploymentName = "shoot-firewall"
+	// StaticIPReservationTag is the tag key for static ips which were reserved for a cluster through the control plane config.
+	// The value consists of the project, the namespace of the shoot in the seed and the name of the reservation, so that the
+	// ip survives the re-creation of a cluster but is not shared with other shoots of the project.
+	StaticIPReservationTag = "cluster.metal-stack.io/static-ip-reservation"
 	// ManagerIdentity is put as a label to every secret managed by the gepm and secretsmanager to make searching easier
 	ManagerIdentity = "provider-" + Type + "-controlplane"
 )
@@ -63,4 +67,7 @@ const (
 type Credentials struct {
 	MetalAPIKey  string
 	MetalAPIHMac string
+	// WorkloadIdentity is true if the api key is a token issued by gardener for a workload identity. such tokens are
+	// rotated by gardener and must only be read from the cloudprovider secret.
+	WorkloadIdentity bool
 }
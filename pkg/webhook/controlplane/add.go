@@ -12,6 +12,7 @@ import (
 	"github.com/gardener/gardener/extensions/pkg/webhook/controlplane/genericmutator"
 	"github.com/go-logr/logr"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/config"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
@@ -158,6 +159,16 @@ func (m *mutator) mutateOperatingSystemConfig(ctx context.Context, gctx gcontext
 		return nil
 	}
 
+	networkAccessType, err := appliedNetworkAccessType(ctx, m.client, cluster, apismetal.NetworkAccessType(*controlPlaneConfig.NetworkAccessType))
+	if err != nil {
+		return err
+	}
+
+	if networkAccessType == apismetal.NetworkAccessBaseline {
+		// a migration from baseline is still pending, the nodes are switched once the firewall was switched
+		return nil
+	}
+
 	if p.NetworkIsolation == nil {
 		return nil
 	}
@@ -190,8 +201,14 @@ func (m *mutator) mutateOperatingSystemConfig(ctx context.Context, gctx gcontext
 			if idx := slices.IndexFunc(oldOSC.Status.ExtensionFiles, func(f extensionsv1alpha1.File) bool {
 				return f.Path == path
 			}); idx >= 0 {
-				dnsServers = p.NetworkIsolation.DNSServers
-				ntpServers = p.NetworkIsolation.NTPServers
+				// the os extension only understands plain ip addresses in this place, the validation of the cloud profile
+				// ensures that the servers use their default ports
+				for _, s := range p.NetworkIsolation.DNSServers {
+					dnsServers = append(dnsServers, s.IP)
+				}
+				for _, s := range p.NetworkIsolation.NTPServers {
+					ntpServers = append(ntpServers, s.IP)
+				}
 				break
 			}
 		}
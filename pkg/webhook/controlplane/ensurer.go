@@ -3,9 +3,11 @@ package controlplane
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/coreos/go-systemd/v22/unit"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	gcontext "github.com/gardener/gardener/extensions/pkg/webhook/context"
 	"github.com/gardener/gardener/extensions/pkg/webhook/controlplane"
@@ -14,16 +16,20 @@ import (
 	versionutils "github.com/gardener/gardener/pkg/utils/version"
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 
 	"github.com/go-logr/logr"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
 
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/imagevector"
 	"github.com/metal-stack/gardener-extension-provider-metal/pkg/metal"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -60,6 +66,17 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, gctx gconte
 		return err
 	}
 
+	template := &new.Spec.Template
+	ps := &template.Spec
+	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-apiserver"); c != nil {
+		ensureKubeAPIServerCommandLineArgs(c, k8sVersion)
+	}
+
+	if v1beta1helper.IsWorkerless(cluster.Shoot) {
+		// workerless shoots have neither an infrastructure nor a vpn to the nodes and no cloudprovider secret
+		return nil
+	}
+
 	infrastructure := &extensionsv1alpha1.Infrastructure{}
 	if err := e.client.Get(ctx, client.ObjectKey{Namespace: cluster.ObjectMeta.Name, Name: cluster.Shoot.Name}, infrastructure); err != nil {
 		logger.Error(err, "could not read Infrastructure for cluster", "cluster name", cluster.ObjectMeta.Name)
@@ -71,11 +88,6 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, gctx gconte
 		return err
 	}
 
-	template := &new.Spec.Template
-	ps := &template.Spec
-	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-apiserver"); c != nil {
-		ensureKubeAPIServerCommandLineArgs(c, k8sVersion)
-	}
 	if c := extensionswebhook.ContainerWithName(ps.Containers, "vpn-seed"); c != nil {
 		ensureVPNSeedEnvVars(c, nodeCIDR)
 	}
@@ -126,6 +138,11 @@ func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, gct
 
 	ensureKubeControllerManagerAnnotations(template)
 
+	if v1beta1helper.IsWorkerless(cluster.Shoot) {
+		// there is no cloudprovider secret for workerless shoots
+		return nil
+	}
+
 	return e.ensureChecksumAnnotations(ctx, &new.Spec.Template, new.Namespace)
 }
 
@@ -240,3 +257,118 @@ func (e *ensurer) EnsureMachineControllerManagerVPA(_ context.Context, _ gcontex
 	)
 	return nil
 }
+
+// EnsureCRIConfig ensures that the containerd registry configuration pulls the images of isolated clusters through the registry mirrors of the partition.
+func (e *ensurer) EnsureCRIConfig(ctx context.Context, gctx gcontext.GardenContext, new, _ *extensionsv1alpha1.CRIConfig) error {
+	if new == nil || new.Name != extensionsv1alpha1.CRINameContainerD {
+		return nil
+	}
+
+	cluster, err := gctx.GetCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("failed reading Cluster: %w", err)
+	}
+
+	controlPlaneConfig, err := helper.ControlPlaneConfigFromClusterShootSpec(cluster)
+	if err != nil {
+		return err
+	}
+
+	networkAccessType, err := appliedNetworkAccessType(ctx, e.client, cluster, pointer.SafeDeref(controlPlaneConfig.NetworkAccessType))
+	if err != nil {
+		return err
+	}
+
+	if networkAccessType == "" || networkAccessType == apismetal.NetworkAccessBaseline {
+		return nil
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	infrastructureConfig, err := helper.InfrastructureConfigFromClusterShootSpec(cluster)
+	if err != nil {
+		return err
+	}
+
+	_, partition, err := helper.FindMetalControlPlane(cloudProfileConfig, infrastructureConfig.PartitionID)
+	if err != nil {
+		return err
+	}
+
+	if partition.NetworkIsolation == nil {
+		return nil
+	}
+
+	if new.Containerd == nil {
+		new.Containerd = &extensionsv1alpha1.ContainerdConfig{}
+	}
+
+	new.Containerd.Registries = ensureRegistryMirrors(new.Containerd.Registries, partition.NetworkIsolation.RegistryMirrors)
+
+	return nil
+}
+
+// ensureRegistryMirrors configures every registry which is mirrored by one of the given registry mirrors to be pulled through the mirror.
+// Registries which are already configured, e.g. by the registry-cache extension, keep their configuration and get the mirror added as
+// an additional host.
+func ensureRegistryMirrors(registries []extensionsv1alpha1.RegistryConfig, mirrors []apismetal.RegistryMirror) []extensionsv1alpha1.RegistryConfig {
+	for _, mirror := range mirrors {
+		for _, upstream := range mirror.MirrorOf {
+			host := extensionsv1alpha1.RegistryHost{
+				URL:          mirror.Endpoint,
+				Capabilities: []extensionsv1alpha1.RegistryCapability{extensionsv1alpha1.PullCapability, extensionsv1alpha1.ResolveCapability},
+			}
+
+			idx := slices.IndexFunc(registries, func(r extensionsv1alpha1.RegistryConfig) bool {
+				return r.Upstream == upstream
+			})
+			if idx < 0 {
+				registries = append(registries, extensionsv1alpha1.RegistryConfig{
+					Upstream: upstream,
+					Server:   new(upstreamServer(upstream)),
+					Hosts:    []extensionsv1alpha1.RegistryHost{host},
+				})
+				continue
+			}
+
+			registry := &registries[idx]
+			if registry.Server == nil {
+				registry.Server = new(upstreamServer(upstream))
+			}
+			if !slices.ContainsFunc(registry.Hosts, func(h extensionsv1alpha1.RegistryHost) bool {
+				return h.URL == host.URL
+			}) {
+				registry.Hosts = append(registry.Hosts, host)
+			}
+		}
+	}
+
+	return registries
+}
+
+// upstreamServer returns the server url of the given upstream registry. docker.io is not served under its own name, the
+// mapping is the same as the one of the registry-cache extension.
+func upstreamServer(upstream string) string {
+	if upstream == "docker.io" {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + upstream
+}
+
+// appliedNetworkAccessType returns the network access type which is applied to the firewall of the shoot. The worker
+// nodes keep the configuration of the applied type while a migration to a stricter type is pending, otherwise they
+// could lose connectivity before the firewall is switched.
+func appliedNetworkAccessType(ctx context.Context, c client.Reader, cluster *extensionscontroller.Cluster, desired apismetal.NetworkAccessType) (apismetal.NetworkAccessType, error) {
+	worker := &extensionsv1alpha1.Worker{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.ObjectMeta.Name, Name: cluster.Shoot.Name}, worker); err != nil {
+		if apierrors.IsNotFound(err) {
+			return desired, nil
+		}
+		return "", fmt.Errorf("unable to get worker: %w", err)
+	}
+
+	return helper.AppliedNetworkAccessType(worker, desired)
+}
@@ -0,0 +1,101 @@
+package controlplane
+
+import (
+	"testing"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	apismetal "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal"
+)
+
+func Test_ensureRegistryMirrors(t *testing.T) {
+	capabilities := []extensionsv1alpha1.RegistryCapability{extensionsv1alpha1.PullCapability, extensionsv1alpha1.ResolveCapability}
+
+	tests := []struct {
+		name       string
+		registries []extensionsv1alpha1.RegistryConfig
+		mirrors    []apismetal.RegistryMirror
+		want       []extensionsv1alpha1.RegistryConfig
+	}{
+		{
+			name:       "no mirrors",
+			registries: nil,
+			mirrors:    nil,
+			want:       nil,
+		},
+		{
+			name: "mirrors are added and merged into existing registries",
+			registries: []extensionsv1alpha1.RegistryConfig{
+				{
+					Upstream: "docker.io",
+					Server:   new("https://registry-1.docker.io"),
+					Hosts:    []extensionsv1alpha1.RegistryHost{{URL: "https://registry-cache"}},
+				},
+				{
+					Upstream: "registry.k8s.io",
+				},
+			},
+			mirrors: []apismetal.RegistryMirror{
+				{
+					Name:     "metal-stack registry",
+					Endpoint: "https://r.metal-stack.dev",
+					MirrorOf: []string{"docker.io", "ghcr.io"},
+				},
+			},
+			want: []extensionsv1alpha1.RegistryConfig{
+				{
+					Upstream: "docker.io",
+					Server:   new("https://registry-1.docker.io"),
+					Hosts: []extensionsv1alpha1.RegistryHost{
+						{URL: "https://registry-cache"},
+						{URL: "https://r.metal-stack.dev", Capabilities: capabilities},
+					},
+				},
+				{
+					Upstream: "registry.k8s.io",
+				},
+				{
+					Upstream: "ghcr.io",
+					Server:   new("https://ghcr.io"),
+					Hosts:    []extensionsv1alpha1.RegistryHost{{URL: "https://r.metal-stack.dev", Capabilities: capabilities}},
+				},
+			},
+		},
+		{
+			name: "docker.io is mapped to its server and mirrors are not added twice",
+			registries: []extensionsv1alpha1.RegistryConfig{
+				{
+					Upstream: "ghcr.io",
+					Hosts:    []extensionsv1alpha1.RegistryHost{{URL: "https://r.metal-stack.dev", Capabilities: capabilities}},
+				},
+			},
+			mirrors: []apismetal.RegistryMirror{
+				{
+					Name:     "metal-stack registry",
+					Endpoint: "https://r.metal-stack.dev",
+					MirrorOf: []string{"ghcr.io", "docker.io"},
+				},
+			},
+			want: []extensionsv1alpha1.RegistryConfig{
+				{
+					Upstream: "ghcr.io",
+					Server:   new("https://ghcr.io"),
+					Hosts:    []extensionsv1alpha1.RegistryHost{{URL: "https://r.metal-stack.dev", Capabilities: capabilities}},
+				},
+				{
+					Upstream: "docker.io",
+					Server:   new("https://registry-1.docker.io"),
+					Hosts:    []extensionsv1alpha1.RegistryHost{{URL: "https://r.metal-stack.dev", Capabilities: capabilities}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ensureRegistryMirrors(tt.registries, tt.mirrors)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (+got -want):\n %s", diff)
+			}
+		})
+	}
+}